@@ -16,4 +16,9 @@ type BuilderWithResponse interface {
 	GetResponseTable() *Table
 }
 
+type builderWithVersionCheck interface {
+	Builder
+	helperVersionCheck(int64) error
+}
+
 //--------------------------------------------------------------------------------//
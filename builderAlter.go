@@ -0,0 +1,177 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------------------------------//
+
+const (
+	builderAlterActionNone = iota
+	builderAlterActionRenameTo
+	builderAlterActionAddColumn
+	builderAlterActionDropColumn
+	builderAlterActionRenameColumn
+	builderAlterActionModifyColumn
+)
+
+type BuilderAlter struct {
+	sqlDialect string
+	alterTable *Table
+	alterName  *string
+	renameName *string
+
+	columnAction   int
+	columnNameFrom *string
+	columnNameTo   *string
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderAlter) SetDialect(sqlDialect string) {
+	builder.sqlDialect = sqlDialect
+}
+
+func (builder *BuilderAlter) Alter(alterTable *Table) *BuilderAlter {
+	builder.alterTable = alterTable
+
+	if alterTable != nil {
+		builder.AlterName(alterTable.GetSqlName())
+	}
+
+	return builder
+}
+
+func (builder *BuilderAlter) AlterName(alterName string) *BuilderAlter {
+	builder.alterName = &alterName
+	return builder
+}
+
+func (builder *BuilderAlter) RenameTo(renameName string) *BuilderAlter {
+	builder.renameName = &renameName
+	return builder.helperColumn(builderAlterActionRenameTo, nil, nil)
+}
+
+func (builder *BuilderAlter) AddColumn(fieldSqlName string) *BuilderAlter {
+	return builder.helperColumn(builderAlterActionAddColumn, nil, &fieldSqlName)
+}
+
+func (builder *BuilderAlter) DropColumn(fieldSqlName string) *BuilderAlter {
+	return builder.helperColumn(builderAlterActionDropColumn, &fieldSqlName, nil)
+}
+
+func (builder *BuilderAlter) RenameColumn(fieldSqlNameFrom string, fieldSqlNameTo string) *BuilderAlter {
+	return builder.helperColumn(builderAlterActionRenameColumn, &fieldSqlNameFrom, &fieldSqlNameTo)
+}
+
+func (builder *BuilderAlter) ModifyColumn(fieldSqlNameFrom string, fieldSqlNameTo string) *BuilderAlter {
+	return builder.helperColumn(builderAlterActionModifyColumn, &fieldSqlNameFrom, &fieldSqlNameTo)
+}
+
+func (builder *BuilderAlter) helperColumn(columnAction int, columnNameFrom *string, columnNameTo *string) *BuilderAlter {
+	if columnAction != builderAlterActionRenameTo {
+		builder.renameName = nil
+	}
+
+	builder.columnAction = columnAction
+	builder.columnNameFrom = columnNameFrom
+	builder.columnNameTo = columnNameTo
+	return builder
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderAlter) helperField() (*TableField, error) {
+	if builder.alterTable == nil {
+		return nil, ErrorBuilderMustHaveATable
+	}
+
+	tableField := builder.alterTable.GetFieldBySqlName(*builder.columnNameTo)
+	if tableField == nil {
+		return nil, ErrorBuilderMustHaveAField
+	}
+
+	return tableField, nil
+}
+
+func (builder *BuilderAlter) helperTableName() string {
+	if builder.renameName != nil {
+		return *builder.renameName
+	}
+
+	if builder.alterName != nil {
+		return *builder.alterName
+	}
+
+	return ""
+}
+
+func (builder *BuilderAlter) Build() (result string, option []interface{}, err error) {
+	var tableField *TableField
+
+	builderAlter := []string{"ALTER TABLE"}
+
+	if builder.alterName == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	builderAlter = append(builderAlter, fmt.Sprintf("`%s`", *builder.alterName))
+
+	switch builder.columnAction {
+	case builderAlterActionRenameTo:
+		builderAlter = append(builderAlter, "RENAME TO", fmt.Sprintf("`%s`", *builder.renameName))
+	case builderAlterActionAddColumn:
+		tableField, err = builder.helperField()
+		if err != nil {
+			return
+		}
+
+		builderAlter = append(builderAlter, "ADD COLUMN", helperBuilderFieldDefine(builder.sqlDialect, tableField))
+	case builderAlterActionDropColumn:
+		builderAlter = append(builderAlter, "DROP COLUMN", fmt.Sprintf("`%s`", *builder.columnNameFrom))
+	case builderAlterActionRenameColumn:
+		builderAlter = append(builderAlter, "RENAME COLUMN", fmt.Sprintf("`%s`", *builder.columnNameFrom), "TO", fmt.Sprintf("`%s`", *builder.columnNameTo))
+	case builderAlterActionModifyColumn:
+		tableField, err = builder.helperField()
+		if err != nil {
+			return
+		}
+
+		switch builder.sqlDialect {
+		case "sqlite":
+			err = ErrorBuilderHasUnsupportedDialect
+			return
+		case "mysql":
+			builderAlter = append(builderAlter, "CHANGE COLUMN", fmt.Sprintf("`%s`", *builder.columnNameFrom), helperBuilderFieldDefine(builder.sqlDialect, tableField))
+		default:
+			builderAlter = append(builderAlter, "CHANGE COLUMN", fmt.Sprintf("`%s`", *builder.columnNameFrom), helperBuilderFieldDefine(builder.sqlDialect, tableField))
+		}
+	default:
+		err = ErrorBuilderMustHaveAnAction
+		return
+	}
+
+	result = strings.Join(builderAlter, " ")
+	return
+}
+
+// --------------------------------------------------------------------------------//
+
+func NewBuilderAlter(alterTable *Table) *BuilderAlter {
+	alterBuilder := &BuilderAlter{
+		sqlDialect: "",
+		alterTable: nil,
+		alterName:  nil,
+		renameName: nil,
+
+		columnAction:   builderAlterActionNone,
+		columnNameFrom: nil,
+		columnNameTo:   nil,
+	}
+
+	return alterBuilder.Alter(alterTable)
+}
+
+// --------------------------------------------------------------------------------//
@@ -0,0 +1,91 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------------------------------//
+
+type BuilderCopy struct {
+	sqlDialect         string
+	copyTable          *Table
+	copyName           *string
+	fromName           *string
+	fromFieldNameArray []string
+	copyFieldNameArray []string
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderCopy) SqlDialect(sqlDialect string) *BuilderCopy {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
+func (builder *BuilderCopy) Copy(copyTable *Table) *BuilderCopy {
+	builder.copyTable = copyTable
+
+	if copyTable != nil {
+		builder.CopyName(copyTable.GetSqlName())
+	}
+
+	return builder
+}
+
+func (builder *BuilderCopy) CopyName(copyName string) *BuilderCopy {
+	builder.copyName = &copyName
+	return builder
+}
+
+func (builder *BuilderCopy) From(fromName string) *BuilderCopy {
+	builder.fromName = &fromName
+	return builder
+}
+
+func (builder *BuilderCopy) Field(fromFieldName string, copyFieldName string) *BuilderCopy {
+	builder.fromFieldNameArray = append(builder.fromFieldNameArray, fromFieldName)
+	builder.copyFieldNameArray = append(builder.copyFieldNameArray, copyFieldName)
+	return builder
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderCopy) Build() (result string, option []interface{}, err error) {
+	builderCopy := []string{"INSERT INTO"}
+
+	if builder.copyName == nil || builder.fromName == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	if len(builder.copyFieldNameArray) == 0 {
+		err = ErrorBuilderMustHaveAField
+		return
+	}
+
+	builderCopy = append(builderCopy, fmt.Sprintf("`%s`", *builder.copyName))
+	builderCopy = append(builderCopy, fmt.Sprintf("(%s)", strings.Join(builder.copyFieldNameArray, ", ")))
+	builderCopy = append(builderCopy, "SELECT", strings.Join(builder.fromFieldNameArray, ", "))
+	builderCopy = append(builderCopy, "FROM", fmt.Sprintf("`%s`", *builder.fromName))
+
+	result = strings.Join(builderCopy, " ")
+	return
+}
+
+// --------------------------------------------------------------------------------//
+
+func NewBuilderCopy(copyTable *Table) *BuilderCopy {
+	copyBuilder := &BuilderCopy{
+		sqlDialect:         "",
+		copyTable:          nil,
+		copyName:           nil,
+		fromName:           nil,
+		fromFieldNameArray: []string{},
+		copyFieldNameArray: []string{},
+	}
+
+	return copyBuilder.Copy(copyTable)
+}
+
+// --------------------------------------------------------------------------------//
@@ -20,6 +20,11 @@ func (builder *BuilderCreate) SetDialect(sqlDialect string) {
 	builder.sqlDialect = sqlDialect
 }
 
+func (builder *BuilderCreate) SqlDialect(sqlDialect string) *BuilderCreate {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
 func (builder *BuilderCreate) IfNotExists(value bool) *BuilderCreate {
 	builder.ifNotExists = value
 	return builder
@@ -59,50 +64,7 @@ func (builder *BuilderCreate) Build() (result string, option []interface{}, err
 
 	for _, fieldGoName := range builder.createTable.GetGoFieldNameArray() {
 		tableField := builder.createTable.GetFieldByGoName(fieldGoName)
-		builderTableDefine := []string{tableField.GetSqlName()}
-
-		if tableField.IsPrimaryKey() && tableField.IsAutoIncrement() {
-			builderTableDefine = append(builderTableDefine, "INTEGER")
-
-			switch builder.sqlDialect {
-			case "sqlite":
-				builderTableDefine = append(builderTableDefine, "PRIMARY KEY")
-			case "mysql":
-				builderTableDefine = append(builderTableDefine, "PRIMARY KEY")
-			default:
-				builderTableDefine = append(builderTableDefine, "PRIMARY_KEY")
-			}
-		} else {
-			builderTableDefine = append(builderTableDefine, tableField.GetSqlType())
-		}
-
-		if tableField.IsAutoIncrement() {
-			switch builder.sqlDialect {
-			case "sqlite":
-				builderTableDefine = append(builderTableDefine, "AUTOINCREMENT")
-			case "mysql":
-				builderTableDefine = append(builderTableDefine, "AUTO_INCREMENT")
-			default:
-				builderTableDefine = append(builderTableDefine, "AUTO_INCREMENT")
-			}
-		}
-
-		if tableField.IsNotNull() {
-			switch builder.sqlDialect {
-			case "sqlite":
-				builderTableDefine = append(builderTableDefine, "NOT NULL")
-			case "mysql":
-				builderTableDefine = append(builderTableDefine, "NOT NULL")
-			default:
-				builderTableDefine = append(builderTableDefine, "NOT_NULL")
-			}
-		}
-
-		if tableField.ValueDefault() != nil {
-			builderTableDefine = append(builderTableDefine, fmt.Sprintf("DEFAULT %s", *tableField.ValueDefault()))
-		}
-
-		builderCreateTableDefine = append(builderCreateTableDefine, strings.Join(builderTableDefine, " "))
+		builderCreateTableDefine = append(builderCreateTableDefine, helperBuilderFieldDefine(builder.sqlDialect, tableField))
 	}
 
 	for _, fieldGoName := range builder.createTable.GetGoFieldNameArray() {
@@ -119,7 +81,7 @@ func (builder *BuilderCreate) Build() (result string, option []interface{}, err
 			primaryFieldArray = append(primaryFieldArray, tableField.GetSqlName())
 		}
 
-		if len(primaryFieldArray) > 1 {
+		if len(primaryFieldArray) > 0 {
 			switch builder.sqlDialect {
 			case "sqlite":
 				builderCreateTableDefine = append(builderCreateTableDefine, fmt.Sprintf("CONSTRAINT %s_pk PRIMARY KEY(%s)", *builder.createName, strings.Join(primaryFieldArray, ", ")))
@@ -141,6 +103,10 @@ func (builder *BuilderCreate) Build() (result string, option []interface{}, err
 		builderCreateTableDefine = append(builderCreateTableDefine, fmt.Sprintf("CONSTRAINT %s_%s_uq UNIQUE(%s)", *builder.createName, uniqueName, strings.Join(uniqueFieldArray, ", ")))
 	}
 
+	for _, tableField := range builder.createTable.GetReferenceArray() {
+		builderCreateTableDefine = append(builderCreateTableDefine, helperBuilderReferenceDefine(builder.sqlDialect, *builder.createName, tableField))
+	}
+
 	builderCreateTable = append(builderCreateTable, fmt.Sprintf("(%s)", strings.Join(builderCreateTableDefine, ", ")))
 	result = strings.Join(builderCreateTable, " ")
 	return
@@ -148,6 +114,93 @@ func (builder *BuilderCreate) Build() (result string, option []interface{}, err
 
 //--------------------------------------------------------------------------------//
 
+func helperBuilderFieldDefine(sqlDialect string, tableField *TableField) string {
+	builderTableDefine := []string{tableField.GetSqlName()}
+
+	if tableField.IsPrimaryKey() && tableField.IsAutoIncrement() {
+		builderTableDefine = append(builderTableDefine, "INTEGER")
+
+		switch sqlDialect {
+		case "sqlite":
+			builderTableDefine = append(builderTableDefine, "PRIMARY KEY")
+		case "mysql":
+			builderTableDefine = append(builderTableDefine, "PRIMARY KEY")
+		default:
+			builderTableDefine = append(builderTableDefine, "PRIMARY_KEY")
+		}
+	} else if strings.EqualFold(tableField.GetSqlType(), "JSON") {
+		switch sqlDialect {
+		case "sqlite":
+			builderTableDefine = append(builderTableDefine, "TEXT")
+		case "mysql":
+			builderTableDefine = append(builderTableDefine, "JSON")
+		default:
+			builderTableDefine = append(builderTableDefine, "JSON")
+		}
+	} else {
+		builderTableDefine = append(builderTableDefine, tableField.GetSqlType())
+	}
+
+	if tableField.IsAutoIncrement() {
+		switch sqlDialect {
+		case "sqlite":
+			builderTableDefine = append(builderTableDefine, "AUTOINCREMENT")
+		case "mysql":
+			builderTableDefine = append(builderTableDefine, "AUTO_INCREMENT")
+		default:
+			builderTableDefine = append(builderTableDefine, "AUTO_INCREMENT")
+		}
+	}
+
+	if tableField.IsNotNull() {
+		switch sqlDialect {
+		case "sqlite":
+			builderTableDefine = append(builderTableDefine, "NOT NULL")
+		case "mysql":
+			builderTableDefine = append(builderTableDefine, "NOT NULL")
+		default:
+			builderTableDefine = append(builderTableDefine, "NOT_NULL")
+		}
+	}
+
+	if tableField.ValueDefault() != nil {
+		builderTableDefine = append(builderTableDefine, fmt.Sprintf("DEFAULT %s", *tableField.ValueDefault()))
+	}
+
+	if strings.EqualFold(tableField.GetSqlType(), "JSON") && sqlDialect == "sqlite" {
+		builderTableDefine = append(builderTableDefine, fmt.Sprintf("CHECK(json_valid(%s))", tableField.GetSqlName()))
+	}
+
+	return strings.Join(builderTableDefine, " ")
+}
+
+func helperBuilderReferenceDefine(sqlDialect string, tableName string, tableField *TableField) string {
+	builderReferenceDefine := []string{fmt.Sprintf("CONSTRAINT %s_%s_fk", tableName, tableField.GetSqlName())}
+
+	switch sqlDialect {
+	case "sqlite":
+		builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("FOREIGN KEY(%s)", tableField.GetSqlName()))
+	case "mysql":
+		builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("FOREIGN KEY(%s)", tableField.GetSqlName()))
+	default:
+		builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("FOREIGN_KEY(%s)", tableField.GetSqlName()))
+	}
+
+	builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("REFERENCES `%s`(%s)", *tableField.ReferenceTable(), *tableField.ReferenceColumn()))
+
+	if tableField.ReferenceOnDelete() != nil {
+		builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("ON DELETE %s", *tableField.ReferenceOnDelete()))
+	}
+
+	if tableField.ReferenceOnUpdate() != nil {
+		builderReferenceDefine = append(builderReferenceDefine, fmt.Sprintf("ON UPDATE %s", *tableField.ReferenceOnUpdate()))
+	}
+
+	return strings.Join(builderReferenceDefine, " ")
+}
+
+//--------------------------------------------------------------------------------//
+
 func NewBuilderCreate(createTable *Table) *BuilderCreate {
 	createBuilder := &BuilderCreate{
 		sqlDialect:  "",
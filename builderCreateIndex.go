@@ -0,0 +1,132 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+type BuilderCreateIndex struct {
+	sqlDialect  string
+	ifNotExists bool
+	createTable *Table
+	createName  *string
+	indexName   *string
+}
+
+//--------------------------------------------------------------------------------//
+
+func (builder *BuilderCreateIndex) SetDialect(sqlDialect string) {
+	builder.sqlDialect = sqlDialect
+}
+
+func (builder *BuilderCreateIndex) SqlDialect(sqlDialect string) *BuilderCreateIndex {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
+func (builder *BuilderCreateIndex) IfNotExists(value bool) *BuilderCreateIndex {
+	builder.ifNotExists = value
+	return builder
+}
+
+func (builder *BuilderCreateIndex) Create(createTable *Table) *BuilderCreateIndex {
+	builder.createTable = createTable
+
+	if createTable != nil {
+		builder.CreateName(createTable.GetSqlName())
+	}
+
+	return builder
+}
+
+func (builder *BuilderCreateIndex) CreateName(createName string) *BuilderCreateIndex {
+	builder.createName = &createName
+	return builder
+}
+
+func (builder *BuilderCreateIndex) Index(indexName string) *BuilderCreateIndex {
+	builder.indexName = &indexName
+	return builder
+}
+
+//--------------------------------------------------------------------------------//
+
+func (builder *BuilderCreateIndex) Build() (result string, option []interface{}, err error) {
+	builderCreateIndex := []string{"CREATE INDEX"}
+	builderIndexColumn := []string{}
+
+	if builder.createTable == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	if builder.indexName == nil {
+		err = ErrorBuilderMustHaveAField
+		return
+	}
+
+	for _, tableField := range builder.createTable.GetIndexArray(*builder.indexName) {
+		if tableField.IsIndexDesc() {
+			builderIndexColumn = append(builderIndexColumn, fmt.Sprintf("%s DESC", tableField.GetSqlName()))
+		} else {
+			builderIndexColumn = append(builderIndexColumn, tableField.GetSqlName())
+		}
+	}
+
+	if len(builderIndexColumn) == 0 {
+		err = ErrorBuilderMustHaveAField
+		return
+	}
+
+	indexWhere := builder.createTable.GetIndexWhere(*builder.indexName)
+
+	switch builder.sqlDialect {
+	case "sqlite":
+		if builder.ifNotExists {
+			builderCreateIndex = append(builderCreateIndex, "IF NOT EXISTS")
+		}
+	case "mysql":
+		if indexWhere != nil {
+			err = fmt.Errorf("%w: INDEX_WHERE", ErrorBuilderHasUnsupportedDialect)
+			return
+		}
+	default:
+		if builder.ifNotExists {
+			builderCreateIndex = append(builderCreateIndex, "IF NOT EXISTS")
+		}
+	}
+
+	builderCreateIndex = append(builderCreateIndex, helperBuilderIndexName(*builder.createName, *builder.indexName))
+	builderCreateIndex = append(builderCreateIndex, fmt.Sprintf("ON `%s`(%s)", *builder.createName, strings.Join(builderIndexColumn, ", ")))
+
+	if indexWhere != nil {
+		builderCreateIndex = append(builderCreateIndex, fmt.Sprintf("WHERE %s", *indexWhere))
+	}
+
+	result = strings.Join(builderCreateIndex, " ")
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperBuilderIndexName(tableName string, indexName string) string {
+	return fmt.Sprintf("%s_%s_ix", tableName, indexName)
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewBuilderCreateIndex(createTable *Table, indexName string) *BuilderCreateIndex {
+	createIndexBuilder := &BuilderCreateIndex{
+		sqlDialect:  "",
+		ifNotExists: false,
+		createTable: nil,
+		createName:  nil,
+		indexName:   nil,
+	}
+
+	return createIndexBuilder.Create(createTable).Index(indexName)
+}
+
+//--------------------------------------------------------------------------------//
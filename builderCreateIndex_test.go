@@ -0,0 +1,82 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testIndexUser struct {
+	Id      int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name    string `sql:"NAME=name | INDEX=name | INDEX_DESC"`
+	Mail    string `sql:"NAME=mail | INDEX=mail | INDEX_WHERE=mail <> ''"`
+	Deleted int64  `sql:"NAME=deleted"`
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestBuilderCreateIndex(t *testing.T) {
+	table, err := NewTable("users", testIndexUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, _, err := NewBuilderCreateIndex(table, "name").SqlDialect("sqlite").IfNotExists(true).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != "CREATE INDEX IF NOT EXISTS users_name_ix ON `users`(name DESC)" {
+		t.Errorf("descending index built as %q", result)
+	}
+
+	result, _, err = NewBuilderCreateIndex(table, "mail").SqlDialect("sqlite").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(result, "WHERE mail <> ''") {
+		t.Errorf("partial index built as %q, want the WHERE clause kept", result)
+	}
+
+	_, _, err = NewBuilderCreateIndex(table, "mail").SqlDialect("mysql").Build()
+	if !errors.Is(err, ErrorBuilderHasUnsupportedDialect) {
+		t.Errorf("partial index on mysql returned %v, want an unsupported dialect error", err)
+	}
+
+	_, _, err = NewBuilderCreateIndex(table, "deleted").SqlDialect("sqlite").Build()
+	if !errors.Is(err, ErrorBuilderMustHaveAField) {
+		t.Errorf("index without columns returned %v, want a missing field error", err)
+	}
+}
+
+func TestBuilderCreateIndexRegister(t *testing.T) {
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "index.db"), 1, nil)
+
+	table, err := database.RegisterTable("users", testIndexUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderCreateIndex(table, "name"))
+	if err == nil {
+		t.Error("registering the table did not create its index")
+	}
+
+	err = database.Execute(NewBuilderDropIndex(table, "name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderCreateIndex(table, "name"))
+	if err != nil {
+		t.Errorf("index was not dropped: %v", err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -11,6 +11,9 @@ type BuilderDelete struct {
 	sqlDialect       string
 	deleteTable      *Table
 	whereStringArray []string
+
+	whereConditionArray []*Condition
+	hardDelete          bool
 }
 
 //--------------------------------------------------------------------------------//
@@ -30,6 +33,16 @@ func (builder *BuilderDelete) Where(whereStringArray ...string) *BuilderDelete {
 	return builder
 }
 
+func (builder *BuilderDelete) WhereCondition(whereConditionArray ...*Condition) *BuilderDelete {
+	builder.whereConditionArray = append(builder.whereConditionArray, whereConditionArray...)
+	return builder
+}
+
+func (builder *BuilderDelete) HardDelete() *BuilderDelete {
+	builder.hardDelete = true
+	return builder
+}
+
 // --------------------------------------------------------------------------------//
 
 func (builder *BuilderDelete) Build() (result string, option []interface{}, err error) {
@@ -40,10 +53,22 @@ func (builder *BuilderDelete) Build() (result string, option []interface{}, err
 		return
 	}
 
+	if softDeleteBuilder := builder.helperSoftDelete(); softDeleteBuilder != nil {
+		return softDeleteBuilder.Build()
+	}
+
 	builderUpdate = append(builderUpdate, fmt.Sprintf("`%s`", builder.deleteTable.GetSqlName()))
 
-	if len(builder.whereStringArray) > 0 {
-		builderUpdate = append(builderUpdate, "WHERE", strings.Join(builder.whereStringArray, " AND "))
+	whereConditionArray, whereConditionOption, whereConditionError := helperConditionArrayBuild(builder.whereConditionArray)
+	if whereConditionError != nil {
+		err = whereConditionError
+		return
+	}
+
+	whereStringArray := append(append([]string{}, builder.whereStringArray...), whereConditionArray...)
+	if len(whereStringArray) > 0 {
+		builderUpdate = append(builderUpdate, "WHERE", strings.Join(whereStringArray, " AND "))
+		option = append(option, whereConditionOption...)
 	}
 
 	result = strings.Join(builderUpdate, " ")
@@ -52,11 +77,29 @@ func (builder *BuilderDelete) Build() (result string, option []interface{}, err
 
 // --------------------------------------------------------------------------------//
 
+func (builder *BuilderDelete) helperSoftDelete() *BuilderUpdate {
+	if builder.hardDelete || builder.deleteTable == nil || builder.deleteTable.GetSoftDelete() == nil {
+		return nil
+	}
+
+	softDeleteBuilder := NewBuilderUpdate(builder.deleteTable).SqlDialect(builder.sqlDialect)
+	softDeleteBuilder.SetValue(builder.deleteTable.GetSoftDelete().GetSqlName(), builder.deleteTable.helperSoftDeleteValue())
+	softDeleteBuilder.Where(builder.whereStringArray...)
+	softDeleteBuilder.WhereCondition(builder.deleteTable.helperSoftDeleteScope(builder.whereConditionArray, tableSoftDeleteScopeDefault)...)
+
+	return softDeleteBuilder
+}
+
+// --------------------------------------------------------------------------------//
+
 func NewBuilderDelete(deleteTable *Table) *BuilderDelete {
 	builderDelete := &BuilderDelete{
 		sqlDialect:       "",
 		deleteTable:      nil,
 		whereStringArray: []string{},
+
+		whereConditionArray: []*Condition{},
+		hardDelete:          false,
 	}
 
 	return builderDelete.Delete(deleteTable)
@@ -0,0 +1,76 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------------------------------//
+
+type BuilderDrop struct {
+	sqlDialect string
+	ifExists   bool
+	dropTable  *Table
+	dropName   *string
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderDrop) SqlDialect(sqlDialect string) *BuilderDrop {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
+func (builder *BuilderDrop) IfExists(value bool) *BuilderDrop {
+	builder.ifExists = value
+	return builder
+}
+
+func (builder *BuilderDrop) Drop(dropTable *Table) *BuilderDrop {
+	builder.dropTable = dropTable
+
+	if dropTable != nil {
+		builder.DropName(dropTable.GetSqlName())
+	}
+
+	return builder
+}
+
+func (builder *BuilderDrop) DropName(dropName string) *BuilderDrop {
+	builder.dropName = &dropName
+	return builder
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderDrop) Build() (result string, option []interface{}, err error) {
+	builderDrop := []string{"DROP TABLE"}
+
+	if builder.dropName == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	if builder.ifExists {
+		builderDrop = append(builderDrop, "IF EXISTS")
+	}
+
+	builderDrop = append(builderDrop, fmt.Sprintf("`%s`", *builder.dropName))
+	result = strings.Join(builderDrop, " ")
+	return
+}
+
+// --------------------------------------------------------------------------------//
+
+func NewBuilderDrop(dropTable *Table) *BuilderDrop {
+	dropBuilder := &BuilderDrop{
+		sqlDialect: "",
+		ifExists:   false,
+		dropTable:  nil,
+		dropName:   nil,
+	}
+
+	return dropBuilder.Drop(dropTable)
+}
+
+// --------------------------------------------------------------------------------//
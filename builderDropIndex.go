@@ -0,0 +1,105 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --------------------------------------------------------------------------------//
+
+type BuilderDropIndex struct {
+	sqlDialect string
+	ifExists   bool
+	dropTable  *Table
+	dropName   *string
+	indexName  *string
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderDropIndex) SetDialect(sqlDialect string) {
+	builder.sqlDialect = sqlDialect
+}
+
+func (builder *BuilderDropIndex) SqlDialect(sqlDialect string) *BuilderDropIndex {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
+func (builder *BuilderDropIndex) IfExists(value bool) *BuilderDropIndex {
+	builder.ifExists = value
+	return builder
+}
+
+func (builder *BuilderDropIndex) Drop(dropTable *Table) *BuilderDropIndex {
+	builder.dropTable = dropTable
+
+	if dropTable != nil {
+		builder.DropName(dropTable.GetSqlName())
+	}
+
+	return builder
+}
+
+func (builder *BuilderDropIndex) DropName(dropName string) *BuilderDropIndex {
+	builder.dropName = &dropName
+	return builder
+}
+
+func (builder *BuilderDropIndex) Index(indexName string) *BuilderDropIndex {
+	builder.indexName = &indexName
+	return builder
+}
+
+// --------------------------------------------------------------------------------//
+
+func (builder *BuilderDropIndex) Build() (result string, option []interface{}, err error) {
+	builderDropIndex := []string{"DROP INDEX"}
+
+	if builder.dropName == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	if builder.indexName == nil {
+		err = ErrorBuilderMustHaveAField
+		return
+	}
+
+	switch builder.sqlDialect {
+	case "sqlite":
+		if builder.ifExists {
+			builderDropIndex = append(builderDropIndex, "IF EXISTS")
+		}
+
+		builderDropIndex = append(builderDropIndex, helperBuilderIndexName(*builder.dropName, *builder.indexName))
+	case "mysql":
+		builderDropIndex = append(builderDropIndex, helperBuilderIndexName(*builder.dropName, *builder.indexName))
+		builderDropIndex = append(builderDropIndex, fmt.Sprintf("ON `%s`", *builder.dropName))
+	default:
+		if builder.ifExists {
+			builderDropIndex = append(builderDropIndex, "IF EXISTS")
+		}
+
+		builderDropIndex = append(builderDropIndex, helperBuilderIndexName(*builder.dropName, *builder.indexName))
+	}
+
+	result = strings.Join(builderDropIndex, " ")
+	return
+}
+
+// --------------------------------------------------------------------------------//
+
+func NewBuilderDropIndex(dropTable *Table, indexName string) *BuilderDropIndex {
+	dropIndexBuilder := &BuilderDropIndex{
+		sqlDialect: "",
+		ifExists:   false,
+		dropTable:  nil,
+		dropName:   nil,
+		indexName:  nil,
+	}
+
+	return dropIndexBuilder.Drop(dropTable).Index(indexName)
+}
+
+// --------------------------------------------------------------------------------//
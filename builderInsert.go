@@ -31,7 +31,7 @@ func (builder *BuilderInsert) Value(insertValue ...interface{}) *BuilderInsert {
 		insertValue = []interface{}{}
 	}
 
-	builder.insertValue = insertValue
+	builder.insertValue = builder.insertTable.helperTimeStampArray(insertValue, true)
 	return builder
 }
 
@@ -63,15 +63,15 @@ func (builder *BuilderInsert) Build() (result string, option []interface{}, err
 		valueReflectValue := reflect.ValueOf(valueUnit)
 
 		if valueReflectValue.Type() != builder.insertTable.GetGoType() {
-			return "", nil, ErroroBuilderTableHasUnsupportedReferense
+			return "", nil, ErrorBuilderValueHasUnsupportedType
 		}
 
 		valueFieldArray := []string{}
 		for _, fieldGoName := range fieldGoNameArray {
 			tableField := builder.insertTable.GetFieldByGoName(fieldGoName)
-			fieldValue := valueReflectValue.FieldByName(fieldGoName)
+			fieldValue := tableField.helperValue(valueReflectValue)
 
-			fieldValueString, err := SqlFieldValueToString(tableField.GetGoType(), fieldValue)
+			fieldValueString, err := tableField.helperValueString(fieldValue)
 			if err != nil {
 				return "", nil, err
 			}
@@ -0,0 +1,62 @@
+package sqlctrl
+
+//--------------------------------------------------------------------------------//
+
+type BuilderRaw struct {
+	sqlDialect    string
+	rawString     string
+	rawOption     []interface{}
+	responseTable *Table
+}
+
+//--------------------------------------------------------------------------------//
+
+func (builder *BuilderRaw) GetResponseTable() *Table {
+	return builder.responseTable
+}
+
+//--------------------------------------------------------------------------------//
+
+func (builder *BuilderRaw) SqlDialect(sqlDialect string) *BuilderRaw {
+	builder.sqlDialect = sqlDialect
+	return builder
+}
+
+func (builder *BuilderRaw) Raw(rawString string, rawOption ...interface{}) *BuilderRaw {
+	builder.rawString = rawString
+	builder.rawOption = rawOption
+	return builder
+}
+
+func (builder *BuilderRaw) Response(responseTable *Table) *BuilderRaw {
+	builder.responseTable = responseTable
+	return builder
+}
+
+//--------------------------------------------------------------------------------//
+
+func (builder *BuilderRaw) Build() (result string, option []interface{}, err error) {
+	if len(builder.rawString) == 0 {
+		err = ErrorBuilderMustHaveAnAction
+		return
+	}
+
+	result = builder.rawString
+	option = append(option, builder.rawOption...)
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewBuilderRaw(rawString string, rawOption ...interface{}) *BuilderRaw {
+	rawBuilder := &BuilderRaw{
+		sqlDialect:    "",
+		rawString:     "",
+		rawOption:     []interface{}{},
+		responseTable: nil,
+	}
+
+	return rawBuilder.Raw(rawString, rawOption...)
+}
+
+//--------------------------------------------------------------------------------//
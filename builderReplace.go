@@ -16,6 +16,10 @@ type BuilderReplace struct {
 
 // --------------------------------------------------------------------------------//
 
+func (builder *BuilderReplace) SetDialect(sqlDialect string) {
+	builder.sqlDialect = sqlDialect
+}
+
 func (builder *BuilderReplace) SqlDialect(sqlDialect string) *BuilderReplace {
 	builder.sqlDialect = sqlDialect
 	return builder
@@ -31,13 +35,73 @@ func (builder *BuilderReplace) Value(replaceValue ...interface{}) *BuilderReplac
 		replaceValue = []interface{}{}
 	}
 
-	builder.replaceValue = replaceValue
+	builder.replaceValue = builder.replaceTable.helperTimeStampArray(replaceValue, true)
 	return builder
 }
 
 // --------------------------------------------------------------------------------//
 
+func (builder *BuilderReplace) helperVersionUpdate() (result string, err error) {
+	versionField := builder.replaceTable.GetVersion()
+	versionName := versionField.GetSqlName()
+
+	fieldSetArray := []string{}
+	for _, fieldGoName := range builder.replaceTable.GetGoFieldNameArray() {
+		tableField := builder.replaceTable.GetFieldByGoName(fieldGoName)
+		fieldName := tableField.GetSqlName()
+
+		if tableField.IsPrimaryKey() || tableField.IsAutoIncrement() || tableField.IsAutoCreateTime() || tableField == versionField {
+			continue
+		}
+
+		switch builder.sqlDialect {
+		case "sqlite":
+			fieldSetArray = append(fieldSetArray, fmt.Sprintf("%s = excluded.%s", fieldName, fieldName))
+		case "mysql":
+			fieldSetArray = append(fieldSetArray, fmt.Sprintf("%s = IF(%s = VALUES(%s), VALUES(%s), %s)", fieldName, versionName, versionName, fieldName, fieldName))
+		}
+	}
+
+	switch builder.sqlDialect {
+	case "sqlite":
+		fieldKeyArray := []string{}
+		for _, tableField := range builder.replaceTable.GetPrimaryKeyArray() {
+			fieldKeyArray = append(fieldKeyArray, tableField.GetSqlName())
+		}
+
+		fieldSetArray = append(fieldSetArray, fmt.Sprintf("%s = `%s`.%s + 1", versionName, builder.replaceTable.GetSqlName(), versionName))
+		result = fmt.Sprintf("ON CONFLICT(%s) DO UPDATE SET %s WHERE `%s`.%s = excluded.%s", strings.Join(fieldKeyArray, ", "), strings.Join(fieldSetArray, ", "), builder.replaceTable.GetSqlName(), versionName, versionName)
+	case "mysql":
+		if len(builder.replaceValue) > 1 {
+			err = fmt.Errorf("%w: versioned replace of %d rows", ErrorBuilderHasUnsupportedDialect, len(builder.replaceValue))
+			return
+		}
+
+		fieldSetArray = append(fieldSetArray, fmt.Sprintf("%s = IF(%s = VALUES(%s), %s + 1, %s)", versionName, versionName, versionName, versionName, versionName))
+		result = fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", strings.Join(fieldSetArray, ", "))
+	default:
+		err = fmt.Errorf("%w: versioned replace on %q", ErrorBuilderHasUnsupportedDialect, builder.sqlDialect)
+	}
+
+	return
+}
+
+// A versioned replace counts one affected row per inserted or updated value
+// and none for a stale version. On MySQL an update counts two, which keeps the
+// check sound only with the driver default clientFoundRows=false.
+func (builder *BuilderReplace) helperVersionCheck(changeCount int64) error {
+	if builder.replaceTable != nil && builder.replaceTable.GetVersion() != nil && changeCount < int64(len(builder.replaceValue)) {
+		return ErrorOptimisticLockConflict
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------------//
+
 func (builder *BuilderReplace) Build() (result string, option []interface{}, err error) {
+	var versionUpdate string
+
 	builderReplace := []string{"REPLACE INTO"}
 
 	if builder.replaceTable == nil {
@@ -45,6 +109,20 @@ func (builder *BuilderReplace) Build() (result string, option []interface{}, err
 		return
 	}
 
+	if builder.replaceTable.GetVersion() != nil {
+		if len(builder.replaceTable.GetPrimaryKeyArray()) == 0 {
+			err = ErrorTableMustHavePrimaryKey
+			return
+		}
+
+		versionUpdate, err = builder.helperVersionUpdate()
+		if err != nil {
+			return
+		}
+
+		builderReplace = []string{"INSERT INTO"}
+	}
+
 	builderReplace = append(builderReplace, fmt.Sprintf("`%s`", builder.replaceTable.GetSqlName()))
 
 	fieldSqlNameArray := []string{}
@@ -59,15 +137,15 @@ func (builder *BuilderReplace) Build() (result string, option []interface{}, err
 		valueReflectValue := reflect.ValueOf(valueUnit)
 
 		if valueReflectValue.Type() != builder.replaceTable.GetGoType() {
-			return "", nil, ErroroBuilderTableHasUnsupportedReferense
+			return "", nil, ErrorBuilderValueHasUnsupportedType
 		}
 
 		valueFieldArray := []string{}
 		for _, fieldGoName := range builder.replaceTable.GetGoFieldNameArray() {
 			tableField := builder.replaceTable.GetFieldByGoName(fieldGoName)
-			fieldValue := valueReflectValue.FieldByName(fieldGoName)
+			fieldValue := tableField.helperValue(valueReflectValue)
 
-			fieldValueString, err := SqlFieldValueToString(tableField.GetGoType(), fieldValue)
+			fieldValueString, err := tableField.helperValueString(fieldValue)
 			if err != nil {
 				return "", nil, err
 			}
@@ -79,6 +157,10 @@ func (builder *BuilderReplace) Build() (result string, option []interface{}, err
 	}
 
 	builderReplace = append(builderReplace, fmt.Sprintf("(%s)", strings.Join(fieldSqlNameArray, ", ")), " VALUES ", strings.Join(builderValueArray, ", "))
+	if len(versionUpdate) > 0 {
+		builderReplace = append(builderReplace, versionUpdate)
+	}
+
 	result = strings.Join(builderReplace, " ")
 	return
 }
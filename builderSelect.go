@@ -8,12 +8,23 @@ import (
 
 //--------------------------------------------------------------------------------//
 
+type builderSelectOrder struct {
+	fieldName string
+	isDesc    bool
+}
+
 type BuilderSelect struct {
 	distinct         bool
 	selectTable      *Table
 	fromSelectArray  []*BuilderSelect
 	fromTableArray   []*Table
 	whereStringArray []string
+
+	whereConditionArray []*Condition
+	orderArray          []builderSelectOrder
+	limit               *int64
+	preloadArray        []string
+	softDeleteScope     int
 }
 
 //--------------------------------------------------------------------------------//
@@ -22,6 +33,10 @@ func (builder *BuilderSelect) GetResponseTable() *Table {
 	return builder.selectTable
 }
 
+func (builder *BuilderSelect) GetPreloadArray() []string {
+	return builder.preloadArray
+}
+
 //--------------------------------------------------------------------------------//
 
 func (builder *BuilderSelect) Distinct(value bool) *BuilderSelect {
@@ -49,6 +64,47 @@ func (builder *BuilderSelect) Where(whereStringArray ...string) *BuilderSelect {
 	return builder
 }
 
+func (builder *BuilderSelect) WhereCondition(whereConditionArray ...*Condition) *BuilderSelect {
+	builder.whereConditionArray = append(builder.whereConditionArray, whereConditionArray...)
+	return builder
+}
+
+func (builder *BuilderSelect) OrderBy(fieldNameArray ...string) *BuilderSelect {
+	for _, fieldName := range fieldNameArray {
+		builder.orderArray = append(builder.orderArray, builderSelectOrder{fieldName: fieldName, isDesc: false})
+	}
+
+	return builder
+}
+
+func (builder *BuilderSelect) OrderByDesc(fieldNameArray ...string) *BuilderSelect {
+	for _, fieldName := range fieldNameArray {
+		builder.orderArray = append(builder.orderArray, builderSelectOrder{fieldName: fieldName, isDesc: true})
+	}
+
+	return builder
+}
+
+func (builder *BuilderSelect) Limit(limit int64) *BuilderSelect {
+	builder.limit = &limit
+	return builder
+}
+
+func (builder *BuilderSelect) WithDeleted() *BuilderSelect {
+	builder.softDeleteScope = tableSoftDeleteScopeWith
+	return builder
+}
+
+func (builder *BuilderSelect) OnlyDeleted() *BuilderSelect {
+	builder.softDeleteScope = tableSoftDeleteScopeOnly
+	return builder
+}
+
+func (builder *BuilderSelect) Preload(preloadArray ...string) *BuilderSelect {
+	builder.preloadArray = append(builder.preloadArray, preloadArray...)
+	return builder
+}
+
 //--------------------------------------------------------------------------------//
 
 func (builder *BuilderSelect) GetType() reflect.Type {
@@ -113,8 +169,34 @@ func (builder *BuilderSelect) Build() (result string, option []interface{}, err
 		builderSelect = append(builderSelect, strings.Join(builderSelectFrom, ", "))
 	}
 
-	if len(builder.whereStringArray) > 0 {
-		builderSelect = append(builderSelect, "WHERE", strings.Join(builder.whereStringArray, " AND "))
+	whereConditionArray, whereConditionOption, whereConditionError := helperConditionArrayBuild(builder.helperWhereConditionArray())
+	if whereConditionError != nil {
+		err = whereConditionError
+		return
+	}
+
+	whereStringArray := append(append([]string{}, builder.whereStringArray...), whereConditionArray...)
+	if len(whereStringArray) > 0 {
+		builderSelect = append(builderSelect, "WHERE", strings.Join(whereStringArray, " AND "))
+		option = append(option, whereConditionOption...)
+	}
+
+	if len(builder.orderArray) > 0 {
+		orderStringArray := []string{}
+
+		for _, orderUnit := range builder.orderArray {
+			if orderUnit.isDesc {
+				orderStringArray = append(orderStringArray, fmt.Sprintf("`%s` DESC", orderUnit.fieldName))
+			} else {
+				orderStringArray = append(orderStringArray, fmt.Sprintf("`%s`", orderUnit.fieldName))
+			}
+		}
+
+		builderSelect = append(builderSelect, "ORDER BY", strings.Join(orderStringArray, ", "))
+	}
+
+	if builder.limit != nil {
+		builderSelect = append(builderSelect, fmt.Sprintf("LIMIT %d", *builder.limit))
 	}
 
 	result = strings.Join(builderSelect, " ")
@@ -123,6 +205,16 @@ func (builder *BuilderSelect) Build() (result string, option []interface{}, err
 
 //--------------------------------------------------------------------------------//
 
+func (builder *BuilderSelect) helperWhereConditionArray() []*Condition {
+	if len(builder.fromSelectArray) > 0 || len(builder.fromTableArray) > 0 {
+		return builder.whereConditionArray
+	}
+
+	return builder.selectTable.helperSoftDeleteScope(builder.whereConditionArray, builder.softDeleteScope)
+}
+
+//--------------------------------------------------------------------------------//
+
 func NewBuilderSelect(selectTable *Table) (selectBuilder *BuilderSelect) {
 	selectBuilder = &BuilderSelect{
 		distinct:         false,
@@ -130,6 +222,12 @@ func NewBuilderSelect(selectTable *Table) (selectBuilder *BuilderSelect) {
 		fromSelectArray:  []*BuilderSelect{},
 		fromTableArray:   []*Table{},
 		whereStringArray: []string{},
+
+		whereConditionArray: []*Condition{},
+		orderArray:          []builderSelectOrder{},
+		limit:               nil,
+		preloadArray:        []string{},
+		softDeleteScope:     tableSoftDeleteScopeDefault,
 	}
 
 	return selectBuilder.Select(selectTable)
@@ -2,6 +2,7 @@ package sqlctrl
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -12,6 +13,12 @@ type BuilderUpdate struct {
 	updateTable      *Table
 	setStringArray   []string
 	whereStringArray []string
+
+	setFieldNameArray   []string
+	setValueArray       []interface{}
+	whereConditionArray []*Condition
+	versionCheck        bool
+	valueError          error
 }
 
 // --------------------------------------------------------------------------------//
@@ -31,11 +38,64 @@ func (builder *BuilderUpdate) Set(setStringArray ...string) *BuilderUpdate {
 	return builder
 }
 
+func (builder *BuilderUpdate) SetValue(fieldName string, value interface{}) *BuilderUpdate {
+	builder.setFieldNameArray = append(builder.setFieldNameArray, fieldName)
+	builder.setValueArray = append(builder.setValueArray, value)
+	return builder
+}
+
+func (builder *BuilderUpdate) Value(updateValue interface{}) *BuilderUpdate {
+	valueReflectValue := reflect.ValueOf(updateValue)
+
+	switch {
+	case builder.updateTable == nil:
+		builder.valueError = ErrorBuilderMustHaveATable
+		return builder
+	case !valueReflectValue.IsValid() || valueReflectValue.Type() != builder.updateTable.GetGoType():
+		builder.valueError = ErrorBuilderValueHasUnsupportedType
+		return builder
+	case len(builder.updateTable.GetPrimaryKeyArray()) == 0:
+		builder.valueError = ErrorTableMustHavePrimaryKey
+		return builder
+	}
+
+	for _, fieldGoName := range builder.updateTable.GetGoFieldNameArray() {
+		tableField := builder.updateTable.GetFieldByGoName(fieldGoName)
+		fieldValue := tableField.helperValue(valueReflectValue)
+
+		switch {
+		case tableField.IsPrimaryKey():
+			builder.WhereCondition(NewConditionEqual(tableField.GetSqlName(), fieldValue.Interface()))
+		case tableField.IsVersion():
+			versionNext := reflect.New(fieldValue.Type()).Elem()
+			if fieldValue.CanInt() {
+				versionNext.SetInt(fieldValue.Int() + 1)
+			} else {
+				versionNext.SetUint(fieldValue.Uint() + 1)
+			}
+
+			builder.WhereCondition(NewConditionEqual(tableField.GetSqlName(), fieldValue.Interface()))
+			builder.SetValue(tableField.GetSqlName(), versionNext.Interface())
+			builder.versionCheck = true
+		case tableField.IsAutoIncrement(), tableField.IsAutoCreateTime(), tableField.IsAutoUpdateTime(), tableField.IsSoftDelete():
+		default:
+			builder.SetValue(tableField.GetSqlName(), fieldValue.Interface())
+		}
+	}
+
+	return builder
+}
+
 func (builder *BuilderUpdate) Where(whereStringArray ...string) *BuilderUpdate {
 	builder.whereStringArray = append(builder.whereStringArray, whereStringArray...)
 	return builder
 }
 
+func (builder *BuilderUpdate) WhereCondition(whereConditionArray ...*Condition) *BuilderUpdate {
+	builder.whereConditionArray = append(builder.whereConditionArray, whereConditionArray...)
+	return builder
+}
+
 // --------------------------------------------------------------------------------//
 
 func (builder *BuilderUpdate) Build() (result string, option []interface{}, err error) {
@@ -48,12 +108,31 @@ func (builder *BuilderUpdate) Build() (result string, option []interface{}, err
 
 	builderUpdate = append(builderUpdate, fmt.Sprintf("`%s`", builder.updateTable.GetSqlName()))
 
-	if len(builder.setStringArray) > 0 {
-		builderUpdate = append(builderUpdate, "SET", strings.Join(builder.setStringArray, ", "))
+	setStringArray := append([]string{}, builder.setStringArray...)
+	setFieldNameArray, setValueArray, err := builder.helperSetArray()
+	if err != nil {
+		return
+	}
+
+	for _, setFieldName := range setFieldNameArray {
+		setStringArray = append(setStringArray, fmt.Sprintf("`%s` = ?", setFieldName))
 	}
 
-	if len(builder.whereStringArray) > 0 {
-		builderUpdate = append(builderUpdate, "WHERE", strings.Join(builder.whereStringArray, " AND "))
+	if len(setStringArray) > 0 {
+		builderUpdate = append(builderUpdate, "SET", strings.Join(setStringArray, ", "))
+		option = append(option, setValueArray...)
+	}
+
+	whereConditionArray, whereConditionOption, whereConditionError := helperConditionArrayBuild(builder.whereConditionArray)
+	if whereConditionError != nil {
+		err = whereConditionError
+		return
+	}
+
+	whereStringArray := append(append([]string{}, builder.whereStringArray...), whereConditionArray...)
+	if len(whereStringArray) > 0 {
+		builderUpdate = append(builderUpdate, "WHERE", strings.Join(whereStringArray, " AND "))
+		option = append(option, whereConditionOption...)
 	}
 
 	result = strings.Join(builderUpdate, " ")
@@ -62,12 +141,57 @@ func (builder *BuilderUpdate) Build() (result string, option []interface{}, err
 
 // --------------------------------------------------------------------------------//
 
+func (builder *BuilderUpdate) helperSetArray() (setFieldNameArray []string, setValueArray []interface{}, err error) {
+	if builder.valueError != nil {
+		err = builder.valueError
+		return
+	}
+
+	setFieldNameArray = append([]string{}, builder.setFieldNameArray...)
+	setValueArray = append([]interface{}{}, builder.setValueArray...)
+
+	if len(setFieldNameArray) == 0 && len(builder.setStringArray) == 0 {
+		return
+	}
+
+	timeFieldNameArray, timeValueArray := builder.updateTable.helperTimeUpdateArray(builder.setStringArray, builder.setFieldNameArray)
+	setFieldNameArray = append(setFieldNameArray, timeFieldNameArray...)
+	setValueArray = append(setValueArray, timeValueArray...)
+
+	for setIndex, setFieldName := range setFieldNameArray {
+		if tableField := builder.updateTable.GetFieldBySqlName(setFieldName); tableField != nil {
+			setValueArray[setIndex], err = tableField.helperValueEncode(reflect.ValueOf(setValueArray[setIndex]))
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (builder *BuilderUpdate) helperVersionCheck(changeCount int64) error {
+	if builder.versionCheck && changeCount == 0 {
+		return ErrorOptimisticLockConflict
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------------//
+
 func NewBuilderUpdate(updateTable *Table) *BuilderUpdate {
 	updateBuilder := &BuilderUpdate{
 		sqlDialect:       "",
 		updateTable:      nil,
 		setStringArray:   []string{},
 		whereStringArray: []string{},
+
+		setFieldNameArray:   []string{},
+		setValueArray:       []interface{}{},
+		whereConditionArray: []*Condition{},
+		versionCheck:        false,
+		valueError:          nil,
 	}
 
 	return updateBuilder.Update(updateTable)
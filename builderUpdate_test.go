@@ -0,0 +1,151 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testVersionRecord struct {
+	Id      int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name    string `sql:"NAME=name"`
+	Version int64  `sql:"NAME=version | VERSION"`
+}
+
+func testVersionOpen(t *testing.T, transport Transport) (*Database, *Table) {
+	t.Helper()
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("records", testVersionRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testVersionRecord{Id: 1, Name: "a", Version: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table
+}
+
+func testVersionQuery(t *testing.T, database *Database, table *Table, id int64) testVersionRecord {
+	t.Helper()
+
+	response, err := database.QueryValue(NewBuilderSelect(table).WhereCondition(NewConditionEqual("id", id)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.(testVersionRecord)
+}
+
+func testVersionRun(t *testing.T, testFunc func(*testing.T, *Database, *Table)) {
+	t.Helper()
+
+	for transportName, transport := range map[string]func() Transport{
+		"sqlite": func() Transport {
+			return NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "version.db"))
+		},
+		"memory": func() Transport {
+			return NewTransportMemory()
+		},
+	} {
+		t.Run(transportName, func(t *testing.T) {
+			database, table := testVersionOpen(t, transport())
+			testFunc(t, database, table)
+		})
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestBuilderUpdateVersion(t *testing.T) {
+	testVersionRun(t, func(t *testing.T, database *Database, table *Table) {
+		err := database.Execute(NewBuilderUpdate(table).Value(testVersionRecord{Id: 1, Name: "b", Version: 1}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record := testVersionQuery(t, database, table, 1); record.Name != "b" || record.Version != 2 {
+			t.Errorf("updated record is %v, want name b at version 2", record)
+		}
+
+		err = database.Execute(NewBuilderUpdate(table).Value(testVersionRecord{Id: 1, Name: "c", Version: 1}))
+		if !errors.Is(err, ErrorOptimisticLockConflict) {
+			t.Errorf("update with a stale version returned %v, want an optimistic lock conflict", err)
+		}
+
+		if record := testVersionQuery(t, database, table, 1); record.Name != "b" {
+			t.Errorf("stale update changed the record to %v", record)
+		}
+	})
+}
+
+func TestBuilderReplaceVersion(t *testing.T) {
+	testVersionRun(t, func(t *testing.T, database *Database, table *Table) {
+		transaction, err := database.TransactionOpen()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = transaction.ExecuteReplaceValue(table,
+			testVersionRecord{Id: 1, Name: "b", Version: 1},
+			testVersionRecord{Id: 2, Name: "new", Version: 1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = transaction.ExecuteReplaceValue(table, testVersionRecord{Id: 1, Name: "c", Version: 1})
+		if !errors.Is(err, ErrorOptimisticLockConflict) {
+			t.Errorf("replace with a stale version returned %v, want an optimistic lock conflict", err)
+		}
+
+		err = transaction.Rollback()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = database.Execute(NewBuilderReplace(table).Value(testVersionRecord{Id: 1, Name: "d", Version: 1}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record := testVersionQuery(t, database, table, 1); record.Name != "d" || record.Version != 2 {
+			t.Errorf("replaced record is %v, want name d at version 2", record)
+		}
+	})
+}
+
+func TestBuilderValueUnsupportedType(t *testing.T) {
+	table, err := NewTable("records", testVersionRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for builderName, builder := range map[string]Builder{
+		"insert":  NewBuilderInsert(table).Value(testMemoryItem{}),
+		"replace": NewBuilderReplace(table).SqlDialect("sqlite").Value(testMemoryItem{}),
+		"update":  NewBuilderUpdate(table).Value(testMemoryItem{}),
+	} {
+		_, _, err = builder.Build()
+		if !errors.Is(err, ErrorBuilderValueHasUnsupportedType) {
+			t.Errorf("%s of a foreign struct returned %v, want an unsupported type error", builderName, err)
+		}
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	sqlctrl "github.com/GlshchnkLx/go-sqlctrl"
+)
+
+//--------------------------------------------------------------------------------//
+
+type generateField struct {
+	goName  string
+	goType  string
+	sqlName string
+	sqlTag  string
+
+	isPrimaryKey bool
+}
+
+type generateTable struct {
+	goName     string
+	sqlName    string
+	fieldArray []*generateField
+}
+
+type generateOption struct {
+	packageName string
+	repository  bool
+}
+
+//--------------------------------------------------------------------------------//
+
+func generateGoName(sqlName string) string {
+	var goName strings.Builder
+
+	for _, namePart := range strings.FieldsFunc(sqlName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		nameRune := []rune(namePart)
+		goName.WriteString(strings.ToUpper(string(nameRune[0])) + string(nameRune[1:]))
+	}
+
+	if goName.Len() == 0 || unicode.IsDigit([]rune(goName.String())[0]) {
+		return "X" + goName.String()
+	}
+
+	return goName.String()
+}
+
+func generateGoType(fieldUnit *sqlctrl.SchemeStorageStable) string {
+	var goType string
+
+	sqlType := strings.ToUpper(fieldUnit.RemoteFieldType)
+
+	switch {
+	case strings.Contains(sqlType, "INT"):
+		goType = "int64"
+	case strings.Contains(sqlType, "REAL") || strings.Contains(sqlType, "FLOA") || strings.Contains(sqlType, "DOUB") || strings.Contains(sqlType, "DEC") || strings.Contains(sqlType, "NUM"):
+		goType = "float64"
+	default:
+		goType = "string"
+	}
+
+	if !fieldUnit.FieldIsNotNull && !fieldUnit.FieldIsPrimaryKey {
+		goType = "*" + goType
+	}
+
+	return goType
+}
+
+func generateSqlTag(fieldUnit *sqlctrl.SchemeStorageStable) string {
+	sqlTagArray := []string{
+		fmt.Sprintf("NAME=%s", fieldUnit.RemoteFieldName),
+		fmt.Sprintf("TYPE=%s", fieldUnit.RemoteFieldType),
+	}
+
+	if fieldUnit.FieldIsPrimaryKey {
+		sqlTagArray = append(sqlTagArray, "PRIMARY_KEY")
+	}
+
+	if fieldUnit.FieldIsAutoIncrement {
+		sqlTagArray = append(sqlTagArray, "AUTO_INCREMENT")
+	}
+
+	if fieldUnit.FieldIsNotNull {
+		sqlTagArray = append(sqlTagArray, "NOT_NULL")
+	}
+
+	if fieldUnit.FieldInUniqueGroup != nil {
+		sqlTagArray = append(sqlTagArray, fmt.Sprintf("UNIQUE_GROUP=%s", *fieldUnit.FieldInUniqueGroup))
+	}
+
+	if fieldUnit.FieldValueDefault != nil {
+		sqlTagArray = append(sqlTagArray, fmt.Sprintf("DEFAULT=%s", *fieldUnit.FieldValueDefault))
+	}
+
+	if fieldUnit.FieldValueCheck != nil {
+		sqlTagArray = append(sqlTagArray, fmt.Sprintf("CHECK=%s", *fieldUnit.FieldValueCheck))
+	}
+
+	sqlTag := fmt.Sprintf("sql:%s", strconv.Quote(strings.Join(sqlTagArray, " | ")))
+	if strings.Contains(sqlTag, "`") {
+		return strconv.Quote(sqlTag)
+	}
+
+	return fmt.Sprintf("`%s`", sqlTag)
+}
+
+func generateTableFromStorage(storageTable *sqlctrl.SchemeStorageTable) *generateTable {
+	var fieldArray []*sqlctrl.SchemeStorageStable
+
+	table := &generateTable{
+		goName:     generateGoName(storageTable.RemoteTableName),
+		sqlName:    storageTable.RemoteTableName,
+		fieldArray: []*generateField{},
+	}
+
+	for _, fieldUnit := range storageTable.FieldMap {
+		fieldArray = append(fieldArray, fieldUnit)
+	}
+
+	sort.Slice(fieldArray, func(i, j int) bool {
+		return fieldArray[i].FieldIndex < fieldArray[j].FieldIndex
+	})
+
+	goNameMap := map[string]int{}
+
+	for _, fieldUnit := range fieldArray {
+		goName := generateGoName(fieldUnit.RemoteFieldName)
+
+		goNameMap[goName]++
+		if goNameMap[goName] > 1 {
+			goName = fmt.Sprintf("%s%d", goName, goNameMap[goName])
+		}
+
+		table.fieldArray = append(table.fieldArray, &generateField{
+			goName:  goName,
+			goType:  generateGoType(fieldUnit),
+			sqlName: fieldUnit.RemoteFieldName,
+			sqlTag:  generateSqlTag(fieldUnit),
+
+			isPrimaryKey: fieldUnit.FieldIsPrimaryKey,
+		})
+	}
+
+	return table
+}
+
+//--------------------------------------------------------------------------------//
+
+func generateSource(option generateOption, tableArray []*generateTable) ([]byte, error) {
+	var source strings.Builder
+
+	fmt.Fprintf(&source, "// Code generated by sqlctrl-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", option.packageName)
+	fmt.Fprintf(&source, "import (\n\tsqlctrl \"github.com/GlshchnkLx/go-sqlctrl\"\n)\n\n")
+
+	for _, table := range tableArray {
+		fmt.Fprintf(&source, "type %s struct {\n", table.goName)
+
+		for _, field := range table.fieldArray {
+			fmt.Fprintf(&source, "\t%s %s %s\n", field.goName, field.goType, field.sqlTag)
+		}
+
+		fmt.Fprintf(&source, "}\n\n")
+	}
+
+	if len(tableArray) > 0 {
+		fmt.Fprintf(&source, "var (\n")
+
+		for _, table := range tableArray {
+			fmt.Fprintf(&source, "\t%sTable *sqlctrl.Table\n", table.goName)
+		}
+
+		fmt.Fprintf(&source, ")\n\n")
+	}
+
+	fmt.Fprintf(&source, "func RegisterTable(database *sqlctrl.Database) (err error) {\n")
+
+	for _, table := range tableArray {
+		fmt.Fprintf(&source, "\t%sTable, err = database.RegisterTable(%s, %s{})\n", table.goName, strconv.Quote(table.sqlName), table.goName)
+		fmt.Fprintf(&source, "\tif err != nil {\n\t\treturn\n\t}\n\n")
+	}
+
+	fmt.Fprintf(&source, "\treturn\n}\n")
+
+	if option.repository {
+		for _, table := range tableArray {
+			generateRepository(&source, table)
+		}
+	}
+
+	return format.Source([]byte(source.String()))
+}
+
+func generateRepository(source *strings.Builder, table *generateTable) {
+	repositoryName := fmt.Sprintf("%sRepository", table.goName)
+
+	fmt.Fprintf(source, "\ntype %s struct {\n\tdatabase *sqlctrl.Database\n}\n\n", repositoryName)
+
+	fmt.Fprintf(source, "func (repository *%s) Select(conditionArray ...*sqlctrl.Condition) ([]%s, error) {\n", repositoryName, table.goName)
+	fmt.Fprintf(source, "\tresponse, err := repository.database.Query(sqlctrl.NewBuilderSelect(%sTable).WhereCondition(conditionArray...))\n", table.goName)
+	fmt.Fprintf(source, "\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+	fmt.Fprintf(source, "\treturn response.([]%s), nil\n}\n\n", table.goName)
+
+	var primaryField *generateField
+	primaryCount := 0
+
+	for _, field := range table.fieldArray {
+		if field.isPrimaryKey {
+			primaryField = field
+			primaryCount++
+		}
+	}
+
+	if primaryCount == 1 {
+		fmt.Fprintf(source, "func (repository *%s) SelectBy%s(value %s) (response %s, err error) {\n", repositoryName, primaryField.goName, primaryField.goType, table.goName)
+		fmt.Fprintf(source, "\tresponseArray, err := repository.Select(sqlctrl.NewConditionEqual(%s, value))\n", strconv.Quote(primaryField.sqlName))
+		fmt.Fprintf(source, "\tif err != nil {\n\t\treturn\n\t}\n\n")
+		fmt.Fprintf(source, "\tswitch len(responseArray) {\n\tcase 0:\n\t\terr = sqlctrl.ErrorResponseLessThanRequested\n\tcase 1:\n\t\tresponse = responseArray[0]\n\tdefault:\n\t\terr = sqlctrl.ErrorResponseMoreThanRequested\n\t}\n\n")
+		fmt.Fprintf(source, "\treturn\n}\n\n")
+	}
+
+	fmt.Fprintf(source, "func (repository *%s) Insert(valueArray ...%s) error {\n", repositoryName, table.goName)
+	fmt.Fprintf(source, "\tif len(valueArray) == 0 {\n\t\treturn nil\n\t}\n\n")
+	fmt.Fprintf(source, "\tinsertArray := make([]interface{}, 0, len(valueArray))\n")
+	fmt.Fprintf(source, "\tfor _, value := range valueArray {\n\t\tinsertArray = append(insertArray, value)\n\t}\n\n")
+	fmt.Fprintf(source, "\treturn repository.database.Execute(sqlctrl.NewBuilderInsert(%sTable).Value(insertArray...))\n}\n\n", table.goName)
+
+	fmt.Fprintf(source, "func (repository *%s) Delete(conditionArray ...*sqlctrl.Condition) error {\n", repositoryName)
+	fmt.Fprintf(source, "\treturn repository.database.Execute(sqlctrl.NewBuilderDelete(%sTable).WhereCondition(conditionArray...))\n}\n\n", table.goName)
+
+	fmt.Fprintf(source, "func New%s(database *sqlctrl.Database) *%s {\n", repositoryName, repositoryName)
+	fmt.Fprintf(source, "\treturn &%s{\n\t\tdatabase: database,\n\t}\n}\n", repositoryName)
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	sqlctrl "github.com/GlshchnkLx/go-sqlctrl"
+	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type tableNameArray []string
+
+func (nameArray *tableNameArray) String() string {
+	return strings.Join(*nameArray, ",")
+}
+
+func (nameArray *tableNameArray) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			*nameArray = append(*nameArray, name)
+		}
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
+
+func run(argArray []string, output io.Writer) (err error) {
+	var (
+		sqlDriver    string
+		sqlSource    string
+		storageName  string
+		outputPath   string
+		option       generateOption
+		includeArray tableNameArray
+		excludeArray tableNameArray
+	)
+
+	fs := flag.NewFlagSet("sqlctrl-gen", flag.ContinueOnError)
+	fs.StringVar(&sqlDriver, "driver", "sqlite", "database driver (sqlite, mysql)")
+	fs.StringVar(&sqlSource, "dsn", "", "database source name")
+	fs.StringVar(&storageName, "storage", "", "scheme storage table to skip together with its history, table, index and lock records")
+	fs.StringVar(&outputPath, "output", "-", "output file, - for stdout")
+	fs.StringVar(&option.packageName, "package", "model", "package name of the generated file")
+	fs.BoolVar(&option.repository, "repository", false, "generate typed repositories")
+	fs.Var(&includeArray, "table", "table to generate, repeatable or comma separated (default all)")
+	fs.Var(&excludeArray, "exclude", "table to skip, repeatable or comma separated")
+
+	err = fs.Parse(argArray)
+	if err != nil {
+		return
+	}
+
+	if len(sqlSource) == 0 {
+		return fmt.Errorf("sqlctrl-gen: -dsn is required")
+	}
+
+	transport := sqlctrl.NewTransportSimple(sqlDriver, sqlSource)
+
+	err = transport.Open()
+	if err != nil {
+		return
+	}
+
+	defer transport.Close()
+
+	transaction, err := transport.TransactionOpen()
+	if err != nil {
+		return
+	}
+
+	defer transaction.Rollback()
+
+	tableNameArray := includeArray
+	if len(tableNameArray) == 0 {
+		tableNameArray, err = sqlctrl.SchemeHelperIntrospectTableNameArray(transaction, transport.GetDialect())
+		if err != nil {
+			return
+		}
+	}
+
+	excludeMap := map[string]bool{}
+	for _, tableName := range excludeArray {
+		excludeMap[tableName] = true
+	}
+
+	if len(storageName) > 0 {
+		excludeMap[storageName] = true
+		for _, storageSuffix := range []string{"history", "table", "index", "lock"} {
+			excludeMap[fmt.Sprintf("%s_%s", storageName, storageSuffix)] = true
+		}
+	}
+
+	tableArray := []*generateTable{}
+
+	for _, tableName := range tableNameArray {
+		if excludeMap[tableName] || strings.HasPrefix(tableName, "_migration_") {
+			continue
+		}
+
+		storageTable, err := sqlctrl.SchemeHelperIntrospect(transaction, transport.GetDialect(), tableName)
+		if err != nil {
+			return err
+		}
+
+		if storageTable == nil {
+			return fmt.Errorf("sqlctrl-gen: table %s is not found", tableName)
+		}
+
+		tableArray = append(tableArray, generateTableFromStorage(storageTable))
+	}
+
+	source, err := generateSource(option, tableArray)
+	if err != nil {
+		return
+	}
+
+	if outputPath == "-" {
+		_, err = output.Write(source)
+		return
+	}
+
+	return os.WriteFile(outputPath, source, 0644)
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+//--------------------------------------------------------------------------------//
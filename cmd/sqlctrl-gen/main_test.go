@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+var testUpdate = flag.Bool("update", false, "rewrite the golden files")
+
+func testGenerateOpen(t *testing.T) string {
+	t.Helper()
+
+	databasePath := filepath.Join(t.TempDir(), "generate.db")
+
+	database, err := sql.Open("sqlite", databasePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer database.Close()
+
+	for _, sqlStatement := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, user_name TEXT(64) NOT NULL UNIQUE, score REAL DEFAULT 0, note TEXT)",
+		"CREATE TABLE order_items (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL, total NUMERIC)",
+		"CREATE TABLE scheme (id INTEGER PRIMARY KEY)",
+		"CREATE TABLE scheme_history (id INTEGER PRIMARY KEY)",
+	} {
+		_, err = database.Exec(sqlStatement)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return databasePath
+}
+
+func testGenerateGolden(t *testing.T, goldenName string, argArray ...string) {
+	t.Helper()
+
+	var output bytes.Buffer
+
+	err := run(append([]string{"-dsn", testGenerateOpen(t)}, argArray...), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goldenPath := filepath.Join("testdata", goldenName)
+
+	if *testUpdate {
+		err = os.WriteFile(goldenPath, output.Bytes(), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(output.Bytes(), golden) {
+		t.Errorf("generated source differs from %s:\n%s", goldenPath, output.Bytes())
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestGenerate(t *testing.T) {
+	testGenerateGolden(t, "model.golden", "-storage", "scheme")
+}
+
+func TestGenerateRepository(t *testing.T) {
+	testGenerateGolden(t, "repository.golden", "-table", "users", "-package", "store", "-repository")
+}
+
+func TestGenerateUnknownTable(t *testing.T) {
+	err := run([]string{"-dsn", testGenerateOpen(t), "-table", "missing"}, &bytes.Buffer{})
+	if err == nil {
+		t.Error("generating a missing table succeeded")
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	sqlctrl "github.com/GlshchnkLx/go-sqlctrl"
+)
+
+//--------------------------------------------------------------------------------//
+
+type statusTable struct {
+	Table            string `json:"table"`
+	Version          int64  `json:"version"`
+	ColumnCount      int    `json:"columns"`
+	IndexCount       int    `json:"indexes"`
+	Hash             string `json:"hash"`
+	MigrationVersion int64  `json:"migration_version"`
+	UpdatedAt        int64  `json:"updated_at"`
+}
+
+type statusReport struct {
+	Storage     string        `json:"storage"`
+	Dialect     string        `json:"dialect"`
+	StepVersion int64         `json:"step_version"`
+	TableArray  []statusTable `json:"tables"`
+}
+
+func (report *statusReport) String() string {
+	var reportText strings.Builder
+
+	fmt.Fprintf(&reportText, "storage %s (%s), step version %d\n", report.Storage, report.Dialect, report.StepVersion)
+
+	for _, table := range report.TableArray {
+		fmt.Fprintf(&reportText, "  %-32s version %-6d columns %-4d indexes %-4d %s step %d at %s\n", table.Table, table.Version, table.ColumnCount, table.IndexCount, table.Hash, table.MigrationVersion, time.Unix(table.UpdatedAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	return reportText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+type historyEntry struct {
+	Version   int64  `json:"version"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Checksum  string `json:"checksum"`
+	AppliedAt int64  `json:"applied_at"`
+}
+
+type historyReport struct {
+	EntryArray []historyEntry `json:"history"`
+}
+
+func (report *historyReport) String() string {
+	var reportText strings.Builder
+
+	for _, entry := range report.EntryArray {
+		fmt.Fprintf(&reportText, "%-6d %-5s %-32s %s %s\n", entry.Version, entry.Kind, entry.Name, entry.Checksum, time.Unix(entry.AppliedAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	return reportText.String()
+}
+
+func newHistoryEntry(historyUnit sqlctrl.SchemeHistoryStable) historyEntry {
+	return historyEntry{
+		Version:   historyUnit.HistoryVersion,
+		Kind:      historyUnit.HistoryKind,
+		Name:      historyUnit.HistoryName,
+		Checksum:  historyUnit.HistoryChecksum,
+		AppliedAt: historyUnit.HistoryAppliedAt,
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+type diffStep struct {
+	Version  int64  `json:"version"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+}
+
+type diffReport struct {
+	PendingArray  []diffStep `json:"pending"`
+	TamperedArray []diffStep `json:"tampered"`
+	UnknownArray  []diffStep `json:"unknown"`
+}
+
+func (report *diffReport) String() string {
+	var reportText strings.Builder
+
+	for _, diffUnit := range []struct {
+		marker    string
+		stepArray []diffStep
+	}{
+		{"+", report.PendingArray},
+		{"!", report.TamperedArray},
+		{"?", report.UnknownArray},
+	} {
+		for _, step := range diffUnit.stepArray {
+			fmt.Fprintf(&reportText, "%s %d %s\n", diffUnit.marker, step.Version, step.Name)
+		}
+	}
+
+	if reportText.Len() == 0 {
+		return "up to date\n"
+	}
+
+	return reportText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+type migrateReport struct {
+	AppliedArray  []historyEntry `json:"applied"`
+	RevertedArray []historyEntry `json:"reverted"`
+}
+
+func (report *migrateReport) String() string {
+	var reportText strings.Builder
+
+	for _, entry := range report.RevertedArray {
+		fmt.Fprintf(&reportText, "- %d %s %s\n", entry.Version, entry.Kind, entry.Name)
+	}
+
+	for _, entry := range report.AppliedArray {
+		fmt.Fprintf(&reportText, "+ %d %s %s\n", entry.Version, entry.Kind, entry.Name)
+	}
+
+	if reportText.Len() == 0 {
+		return "nothing to migrate\n"
+	}
+
+	return reportText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+type dumpReport struct {
+	SqlArray []string `json:"sql"`
+}
+
+func (report *dumpReport) String() string {
+	var reportText strings.Builder
+
+	for _, sqlUnit := range report.SqlArray {
+		fmt.Fprintf(&reportText, "%s;\n", sqlUnit)
+	}
+
+	return reportText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+func loadRegistry(dirName string) (*sqlctrl.SchemeMigrationRegistry, error) {
+	registry := sqlctrl.NewSchemeMigrationRegistry()
+
+	if len(dirName) == 0 {
+		return nil, fmt.Errorf("sqlctrl: -dir is required")
+	}
+
+	err := registry.RegisterFS(os.DirFS(dirName), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+func historyKey(historyUnit sqlctrl.SchemeHistoryStable) string {
+	return fmt.Sprintf("%d/%s/%s", historyUnit.HistoryVersion, historyUnit.HistoryKind, historyUnit.HistoryName)
+}
+
+//--------------------------------------------------------------------------------//
+
+func commandStatus(option commandOption, argArray []string, writer io.Writer) error {
+	database, scheme, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	historyArray, err := scheme.History()
+	if err != nil {
+		return err
+	}
+
+	report := &statusReport{
+		Storage:    option.storageName,
+		Dialect:    database.GetDialect(),
+		TableArray: []statusTable{},
+	}
+
+	for _, historyUnit := range historyArray {
+		if historyUnit.HistoryKind == sqlctrl.SchemeHistoryKindStep && historyUnit.HistoryVersion > report.StepVersion {
+			report.StepVersion = historyUnit.HistoryVersion
+		}
+	}
+
+	for _, storageTable := range scheme.GetStorageTableArray() {
+		report.TableArray = append(report.TableArray, statusTable{
+			Table:            storageTable.RemoteTableName,
+			Version:          storageTable.SchemeVersion,
+			ColumnCount:      len(storageTable.FieldMap),
+			IndexCount:       len(storageTable.IndexMap),
+			Hash:             storageTable.GetHash(),
+			MigrationVersion: storageTable.MigrationVersion,
+			UpdatedAt:        storageTable.UpdatedAt,
+		})
+	}
+
+	return writeReport(option, writer, report)
+}
+
+func commandDiff(option commandOption, argArray []string, writer io.Writer) error {
+	var dirName string
+
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.StringVar(&dirName, "dir", "", "migration directory")
+
+	err := fs.Parse(argArray)
+	if err != nil {
+		return err
+	}
+
+	registry, err := loadRegistry(dirName)
+	if err != nil {
+		return err
+	}
+
+	database, scheme, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	historyArray, err := scheme.History()
+	if err != nil {
+		return err
+	}
+
+	report := &diffReport{
+		PendingArray:  []diffStep{},
+		TamperedArray: []diffStep{},
+		UnknownArray:  []diffStep{},
+	}
+
+	historyMap := map[int64]sqlctrl.SchemeHistoryStable{}
+	for _, historyUnit := range historyArray {
+		if historyUnit.HistoryKind != sqlctrl.SchemeHistoryKindStep {
+			continue
+		}
+
+		historyMap[historyUnit.HistoryVersion] = historyUnit
+
+		if registry.GetMigration(historyUnit.HistoryVersion) == nil {
+			report.UnknownArray = append(report.UnknownArray, diffStep{Version: historyUnit.HistoryVersion, Name: historyUnit.HistoryName, Checksum: historyUnit.HistoryChecksum})
+		}
+	}
+
+	for _, migration := range registry.GetMigrationArray() {
+		historyUnit, applied := historyMap[migration.Version]
+
+		switch {
+		case !applied:
+			report.PendingArray = append(report.PendingArray, diffStep{Version: migration.Version, Name: migration.Name, Checksum: migration.Checksum})
+		case historyUnit.HistoryChecksum != migration.Checksum:
+			report.TamperedArray = append(report.TamperedArray, diffStep{Version: migration.Version, Name: migration.Name, Checksum: migration.Checksum})
+		}
+	}
+
+	return writeReport(option, writer, report)
+}
+
+func commandMigrate(option commandOption, argArray []string, writer io.Writer) error {
+	var (
+		dirName   string
+		versionTo int64
+	)
+
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.StringVar(&dirName, "dir", "", "migration directory")
+	fs.Int64Var(&versionTo, "to", -1, "target version, migrates down when lower than the applied one")
+
+	err := fs.Parse(argArray)
+	if err != nil {
+		return err
+	}
+
+	if len(dirName) == 0 && versionTo < 0 {
+		return fmt.Errorf("sqlctrl: -dir or -to is required")
+	}
+
+	database, scheme, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	historyArrayBefore, err := scheme.History()
+	if err != nil {
+		return err
+	}
+
+	if len(dirName) > 0 {
+		registry, err := loadRegistry(dirName)
+		if err != nil {
+			return err
+		}
+
+		if versionTo >= 0 {
+			registryTo := sqlctrl.NewSchemeMigrationRegistry()
+
+			for _, migration := range registry.GetMigrationArray() {
+				if migration.Version <= versionTo {
+					err = registryTo.Register(migration)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			registry = registryTo
+		}
+
+		err = scheme.RegisterMigration(registry)
+		if err != nil {
+			return err
+		}
+
+		err = scheme.Migrate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if versionTo >= 0 {
+		err = database.MigrateTo(versionTo)
+		if err != nil {
+			return err
+		}
+	}
+
+	historyArrayAfter, err := scheme.History()
+	if err != nil {
+		return err
+	}
+
+	report := &migrateReport{
+		AppliedArray:  []historyEntry{},
+		RevertedArray: []historyEntry{},
+	}
+
+	historyMapBefore := map[string]bool{}
+	for _, historyUnit := range historyArrayBefore {
+		historyMapBefore[historyKey(historyUnit)] = true
+	}
+
+	historyMapAfter := map[string]bool{}
+	for _, historyUnit := range historyArrayAfter {
+		historyMapAfter[historyKey(historyUnit)] = true
+
+		if !historyMapBefore[historyKey(historyUnit)] {
+			report.AppliedArray = append(report.AppliedArray, newHistoryEntry(historyUnit))
+		}
+	}
+
+	for index := len(historyArrayBefore) - 1; index >= 0; index-- {
+		if !historyMapAfter[historyKey(historyArrayBefore[index])] {
+			report.RevertedArray = append(report.RevertedArray, newHistoryEntry(historyArrayBefore[index]))
+		}
+	}
+
+	return writeReport(option, writer, report)
+}
+
+func commandHistory(option commandOption, argArray []string, writer io.Writer) error {
+	database, scheme, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	historyArray, err := scheme.History()
+	if err != nil {
+		return err
+	}
+
+	report := &historyReport{EntryArray: []historyEntry{}}
+	for _, historyUnit := range historyArray {
+		report.EntryArray = append(report.EntryArray, newHistoryEntry(historyUnit))
+	}
+
+	return writeReport(option, writer, report)
+}
+
+func commandDumpDdl(option commandOption, argArray []string, writer io.Writer) error {
+	var sqlDialect string
+
+	fs := flag.NewFlagSet("dump-ddl", flag.ContinueOnError)
+	fs.StringVar(&sqlDialect, "dialect", "", "target dialect (sqlite, mysql), defaults to the connected one")
+
+	err := fs.Parse(argArray)
+	if err != nil {
+		return err
+	}
+
+	database, scheme, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	if len(sqlDialect) == 0 {
+		sqlDialect = database.GetDialect()
+	}
+
+	tableArray := []*sqlctrl.Table{}
+
+	for _, storageTable := range scheme.GetStorageTableArray() {
+		table, err := sqlctrl.SchemeHelperFieldMapToTable(storageTable)
+		if err != nil {
+			return err
+		}
+
+		tableArray = append(tableArray, table)
+	}
+
+	sqlArray, err := sqlctrl.SchemeHelperDumpSchemaArray(sqlDialect, tableArray)
+	if err != nil {
+		return err
+	}
+
+	return writeReport(option, writer, &dumpReport{SqlArray: append([]string{}, sqlArray...)})
+}
+
+func commandVerify(option commandOption, argArray []string, writer io.Writer) error {
+	database, _, err := openDatabase(option)
+	if err != nil {
+		return err
+	}
+
+	defer database.Close()
+
+	verify, err := database.Verify()
+	if err != nil {
+		return err
+	}
+
+	err = writeReport(option, writer, verify)
+	if err != nil {
+		return err
+	}
+
+	if verify.HasDrift() {
+		return errorDrift
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sqlctrl "github.com/GlshchnkLx/go-sqlctrl"
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testCommandUser struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+}
+
+func testCommandOpen(t *testing.T) commandOption {
+	t.Helper()
+
+	option := commandOption{
+		sqlDriver:      "sqlite",
+		sqlSource:      filepath.Join(t.TempDir(), "command.db"),
+		storageName:    "scheme",
+		storageVersion: 1,
+		jsonOutput:     true,
+	}
+
+	database, err := sqlctrl.NewDatabase(sqlctrl.NewTransportSimple(option.sqlDriver, option.sqlSource), sqlctrl.NewSchemeDatabase(option.storageName, option.storageVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer database.Close()
+
+	_, err = database.RegisterTable("users", testCommandUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return option
+}
+
+func testCommandDir(t *testing.T) string {
+	t.Helper()
+
+	dirName := t.TempDir()
+
+	for fileName, fileData := range map[string]string{
+		"1_create_tags.up.sql":   "CREATE TABLE tags (id INTEGER PRIMARY KEY)",
+		"1_create_tags.down.sql": "DROP TABLE tags",
+	} {
+		err := os.WriteFile(filepath.Join(dirName, fileName), []byte(fileData), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dirName
+}
+
+func testCommandRun(t *testing.T, commandRun func(commandOption, []string, io.Writer) error, option commandOption, report interface{}, argArray ...string) {
+	t.Helper()
+
+	var output bytes.Buffer
+
+	err := commandRun(option, argArray, &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = json.Unmarshal(output.Bytes(), report)
+	if err != nil {
+		t.Fatalf("%v:\n%s", err, output.Bytes())
+	}
+}
+
+func testCommandHasStep(entryArray []historyEntry, version int64) bool {
+	for _, entry := range entryArray {
+		if entry.Kind == sqlctrl.SchemeHistoryKindStep && entry.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestCommandStatus(t *testing.T) {
+	var report statusReport
+
+	testCommandRun(t, commandStatus, testCommandOpen(t), &report)
+
+	for _, table := range report.TableArray {
+		if table.Table == "users" && table.ColumnCount == 2 {
+			return
+		}
+	}
+
+	t.Errorf("status reports %+v, want the users table with 2 columns", report.TableArray)
+}
+
+func TestCommandMigrate(t *testing.T) {
+	var (
+		option      = testCommandOpen(t)
+		dirName     = testCommandDir(t)
+		diffBefore  diffReport
+		diffAfter   diffReport
+		migrateUp   migrateReport
+		migrateDown migrateReport
+		history     historyReport
+	)
+
+	testCommandRun(t, commandDiff, option, &diffBefore, "-dir", dirName)
+
+	if len(diffBefore.PendingArray) != 1 || diffBefore.PendingArray[0].Name != "create_tags" {
+		t.Errorf("diff reports %+v pending, want create_tags", diffBefore.PendingArray)
+	}
+
+	testCommandRun(t, commandMigrate, option, &migrateUp, "-dir", dirName)
+
+	if !testCommandHasStep(migrateUp.AppliedArray, 1) {
+		t.Errorf("migrate applied %+v, want step 1", migrateUp.AppliedArray)
+	}
+
+	testCommandRun(t, commandDiff, option, &diffAfter, "-dir", dirName)
+
+	if len(diffAfter.PendingArray) != 0 {
+		t.Errorf("diff reports %+v pending after the migrate", diffAfter.PendingArray)
+	}
+
+	testCommandRun(t, commandMigrate, option, &migrateDown, "-dir", dirName, "-to", "0")
+
+	if !testCommandHasStep(migrateDown.RevertedArray, 1) {
+		t.Errorf("migrate down reverted %+v, want step 1", migrateDown.RevertedArray)
+	}
+
+	testCommandRun(t, commandHistory, option, &history)
+
+	if testCommandHasStep(history.EntryArray, 1) {
+		t.Errorf("history keeps the reverted step: %+v", history.EntryArray)
+	}
+}
+
+func TestCommandDumpDdl(t *testing.T) {
+	var report dumpReport
+
+	testCommandRun(t, commandDumpDdl, testCommandOpen(t), &report, "-dialect", "mysql")
+
+	for _, sqlUnit := range report.SqlArray {
+		if strings.HasPrefix(sqlUnit, "CREATE TABLE `users`") {
+			return
+		}
+	}
+
+	t.Errorf("dump-ddl reports %v, want the users table", report.SqlArray)
+}
+
+func TestCommandVerify(t *testing.T) {
+	option := testCommandOpen(t)
+
+	err := commandVerify(option, nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDb, err := sql.Open(option.sqlDriver, option.sqlSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer sqlDb.Close()
+
+	_, err = sqlDb.Exec("ALTER TABLE users ADD COLUMN extra TEXT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = commandVerify(option, nil, &bytes.Buffer{})
+	if !errors.Is(err, errorDrift) {
+		t.Errorf("verify of a drifted database returned %v, want a drift error", err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
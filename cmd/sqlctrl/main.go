@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	sqlctrl "github.com/GlshchnkLx/go-sqlctrl"
+	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+var errorDrift = errors.New("sqlctrl: drift detected")
+
+type commandOption struct {
+	sqlDriver      string
+	sqlSource      string
+	storageName    string
+	storageVersion int64
+	jsonOutput     bool
+}
+
+type commandReport interface {
+	String() string
+}
+
+type command struct {
+	name        string
+	description string
+	run         func(commandOption, []string, io.Writer) error
+}
+
+var commandArray = []command{
+	{"status", "list tables stored in the scheme storage with versions and hashes", commandStatus},
+	{"diff", "compare migration files with the applied history (-dir)", commandDiff},
+	{"migrate", "apply migration files (-dir), optionally up or down to a version (-to)", commandMigrate},
+	{"history", "list the applied migration history", commandHistory},
+	{"dump-ddl", "print CREATE TABLE statements for the stored scheme (-dialect)", commandDumpDdl},
+	{"verify", "compare the live database with the stored scheme", commandVerify},
+}
+
+//--------------------------------------------------------------------------------//
+
+func openDatabase(option commandOption) (*sqlctrl.Database, sqlctrl.SchemeWithMigration, error) {
+	scheme, ok := sqlctrl.NewSchemeDatabase(option.storageName, option.storageVersion).(sqlctrl.SchemeWithMigration)
+	if !ok {
+		return nil, nil, sqlctrl.ErrorSchemeHasUnsupportedMigration
+	}
+
+	database, err := sqlctrl.NewDatabase(sqlctrl.NewTransportSimple(option.sqlDriver, option.sqlSource), scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return database, scheme, nil
+}
+
+func writeReport(option commandOption, writer io.Writer, report commandReport) error {
+	if option.jsonOutput {
+		reportJson, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(writer, "%s\n", reportJson)
+		return err
+	}
+
+	_, err := io.WriteString(writer, report.String())
+	return err
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: sqlctrl [flags] <command> [command flags]\n\ncommands:\n")
+
+	for _, commandUnit := range commandArray {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %-10s %s\n", commandUnit.name, commandUnit.description)
+	}
+
+	fmt.Fprintf(flag.CommandLine.Output(), "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+//--------------------------------------------------------------------------------//
+
+func run() error {
+	var option commandOption
+
+	flag.StringVar(&option.sqlDriver, "driver", "sqlite", "database driver (sqlite, mysql)")
+	flag.StringVar(&option.sqlSource, "dsn", "", "database source name")
+	flag.StringVar(&option.storageName, "storage", "scheme", "scheme storage table name")
+	flag.Int64Var(&option.storageVersion, "version", 1, "scheme storage version")
+	flag.BoolVar(&option.jsonOutput, "json", false, "machine-readable JSON output")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		return fmt.Errorf("sqlctrl: command is required")
+	}
+
+	if len(option.sqlSource) == 0 {
+		return fmt.Errorf("sqlctrl: -dsn is required")
+	}
+
+	for _, commandUnit := range commandArray {
+		if commandUnit.name == flag.Arg(0) {
+			return commandUnit.run(option, flag.Args()[1:], os.Stdout)
+		}
+	}
+
+	return fmt.Errorf("sqlctrl: unknown command %s", flag.Arg(0))
+}
+
+func main() {
+	err := run()
+
+	switch {
+	case err == nil:
+	case errors.Is(err, errorDrift):
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,199 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	ConditionOperatorEqual          = "="
+	ConditionOperatorNotEqual       = "<>"
+	ConditionOperatorLess           = "<"
+	ConditionOperatorLessOrEqual    = "<="
+	ConditionOperatorGreater        = ">"
+	ConditionOperatorGreaterOrEqual = ">="
+	ConditionOperatorIn             = "IN"
+	ConditionOperatorIsNull         = "IS NULL"
+	ConditionOperatorIsNotNull      = "IS NOT NULL"
+)
+
+//--------------------------------------------------------------------------------//
+
+type Condition struct {
+	fieldName  string
+	fieldPath  *string
+	operator   string
+	valueArray []interface{}
+}
+
+//--------------------------------------------------------------------------------//
+
+func (condition *Condition) GetFieldName() string {
+	return condition.fieldName
+}
+
+func (condition *Condition) GetFieldPath() *string {
+	return condition.fieldPath
+}
+
+func (condition *Condition) GetOperator() string {
+	return condition.operator
+}
+
+func (condition *Condition) GetValueArray() []interface{} {
+	return condition.valueArray
+}
+
+//--------------------------------------------------------------------------------//
+
+func (condition *Condition) Build() (result string, option []interface{}, err error) {
+	if len(condition.fieldName) == 0 {
+		err = ErrorConditionMustHaveAField
+		return
+	}
+
+	fieldString := fmt.Sprintf("`%s`", condition.fieldName)
+	if condition.fieldPath != nil {
+		fieldString = fmt.Sprintf("json_extract(%s, ?)", fieldString)
+		option = append(option, *condition.fieldPath)
+	}
+
+	switch condition.operator {
+	case ConditionOperatorIsNull, ConditionOperatorIsNotNull:
+		result = fmt.Sprintf("%s %s", fieldString, condition.operator)
+	case ConditionOperatorIn:
+		if len(condition.valueArray) == 0 {
+			result, option = "1 = 0", nil
+			return
+		}
+
+		result = fmt.Sprintf("%s IN (%s)", fieldString, strings.TrimSuffix(strings.Repeat("?, ", len(condition.valueArray)), ", "))
+		option = append(option, helperConditionValueArray(condition.valueArray)...)
+	case ConditionOperatorEqual, ConditionOperatorNotEqual, ConditionOperatorLess, ConditionOperatorLessOrEqual, ConditionOperatorGreater, ConditionOperatorGreaterOrEqual:
+		if len(condition.valueArray) != 1 {
+			err = ErrorConditionHasUnsupportedValue
+			return
+		}
+
+		result = fmt.Sprintf("%s %s ?", fieldString, condition.operator)
+		option = append(option, helperConditionValueArray(condition.valueArray)...)
+	default:
+		err = ErrorConditionHasUnsupportedOperator
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperConditionValueArray(valueArray []interface{}) (resultArray []interface{}) {
+	for _, value := range valueArray {
+		switch valueTime := value.(type) {
+		case time.Time:
+			value = valueTime.UTC().Format(TableTimeLayout)
+		case *time.Time:
+			if valueTime != nil {
+				value = valueTime.UTC().Format(TableTimeLayout)
+			}
+		}
+
+		resultArray = append(resultArray, value)
+	}
+
+	return
+}
+
+func helperConditionArrayBuild(conditionArray []*Condition) (resultArray []string, option []interface{}, err error) {
+	for _, condition := range conditionArray {
+		if condition == nil {
+			err = ErrorConditionIsNil
+			return
+		}
+
+		conditionResult, conditionOption, conditionError := condition.Build()
+		if conditionError != nil {
+			err = conditionError
+			return
+		}
+
+		resultArray = append(resultArray, conditionResult)
+		option = append(option, conditionOption...)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewCondition(fieldName string, operator string, valueArray ...interface{}) *Condition {
+	return &Condition{
+		fieldName:  fieldName,
+		fieldPath:  nil,
+		operator:   operator,
+		valueArray: valueArray,
+	}
+}
+
+func NewConditionJson(fieldName string, fieldPath string, operator string, valueArray ...interface{}) *Condition {
+	return &Condition{
+		fieldName:  fieldName,
+		fieldPath:  &fieldPath,
+		operator:   operator,
+		valueArray: valueArray,
+	}
+}
+
+func NewConditionEqual(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorEqual, value)
+}
+
+func NewConditionNotEqual(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorNotEqual, value)
+}
+
+func NewConditionLess(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorLess, value)
+}
+
+func NewConditionLessOrEqual(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorLessOrEqual, value)
+}
+
+func NewConditionGreater(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorGreater, value)
+}
+
+func NewConditionGreaterOrEqual(fieldName string, value interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorGreaterOrEqual, value)
+}
+
+func NewConditionIn(fieldName string, valueArray ...interface{}) *Condition {
+	return NewCondition(fieldName, ConditionOperatorIn, valueArray...)
+}
+
+func NewConditionIsNull(fieldName string) *Condition {
+	return NewCondition(fieldName, ConditionOperatorIsNull)
+}
+
+func NewConditionIsNotNull(fieldName string) *Condition {
+	return NewCondition(fieldName, ConditionOperatorIsNotNull)
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewConditionJsonEqual(fieldName string, fieldPath string, value interface{}) *Condition {
+	return NewConditionJson(fieldName, fieldPath, ConditionOperatorEqual, value)
+}
+
+func NewConditionJsonIn(fieldName string, fieldPath string, valueArray ...interface{}) *Condition {
+	return NewConditionJson(fieldName, fieldPath, ConditionOperatorIn, valueArray...)
+}
+
+func NewConditionJsonIsNull(fieldName string, fieldPath string) *Condition {
+	return NewConditionJson(fieldName, fieldPath, ConditionOperatorIsNull)
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,104 @@
+package sqlctrl
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testJsonMeta struct {
+	Color string `json:"color"`
+	Size  int64  `json:"size"`
+}
+
+type testJsonDocument struct {
+	Id   int64             `sql:"NAME=id | PRIMARY_KEY"`
+	Attr map[string]string `sql:"NAME=attr"`
+	Tags []string          `sql:"NAME=tags"`
+	Meta testJsonMeta      `sql:"NAME=meta | JSON"`
+}
+
+func testJsonOpen(t *testing.T, transport Transport) (*Database, *Table) {
+	t.Helper()
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("documents", testJsonDocument{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(
+		testJsonDocument{Id: 1, Attr: map[string]string{"kind": "a"}, Tags: []string{"x", "y"}, Meta: testJsonMeta{Color: "red", Size: 1}},
+		testJsonDocument{Id: 2, Attr: map[string]string{"kind": "b"}, Tags: []string{}, Meta: testJsonMeta{Color: "blue", Size: 2}},
+		testJsonDocument{Id: 3, Attr: map[string]string{}, Tags: []string{"z"}, Meta: testJsonMeta{Color: "red", Size: 3}},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table
+}
+
+func testJsonQuery(t *testing.T, database *Database, table *Table, condition *Condition) []testJsonDocument {
+	t.Helper()
+
+	response, err := database.Query(NewBuilderSelect(table).WhereCondition(condition).OrderBy("id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.([]testJsonDocument)
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestConditionJson(t *testing.T) {
+	for transportName, transport := range map[string]func() Transport{
+		"sqlite": func() Transport {
+			return NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "json.db"))
+		},
+		"memory": func() Transport {
+			return NewTransportMemory()
+		},
+	} {
+		t.Run(transportName, func(t *testing.T) {
+			database, table := testJsonOpen(t, transport())
+
+			documentArray := testJsonQuery(t, database, table, NewConditionEqual("id", 1))
+			if len(documentArray) != 1 {
+				t.Fatalf("query returned %d documents, want 1", len(documentArray))
+			}
+
+			document := testJsonDocument{Id: 1, Attr: map[string]string{"kind": "a"}, Tags: []string{"x", "y"}, Meta: testJsonMeta{Color: "red", Size: 1}}
+			if !reflect.DeepEqual(documentArray[0], document) {
+				t.Errorf("document read back as %v, want %v", documentArray[0], document)
+			}
+
+			if documentCount := len(testJsonQuery(t, database, table, NewConditionJsonEqual("meta", "$.color", "red"))); documentCount != 2 {
+				t.Errorf("json equal matched %d documents, want 2", documentCount)
+			}
+
+			if documentCount := len(testJsonQuery(t, database, table, NewConditionJsonIn("meta", "$.size", 2, 3))); documentCount != 2 {
+				t.Errorf("json in matched %d documents, want 2", documentCount)
+			}
+
+			if documentCount := len(testJsonQuery(t, database, table, NewConditionJsonIsNull("attr", "$.kind"))); documentCount != 1 {
+				t.Errorf("json is null matched %d documents, want 1", documentCount)
+			}
+		})
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -1,8 +1,9 @@
 package sqlctrl
 
 import (
-	"fmt"
+	"io"
 	"reflect"
+	"time"
 )
 
 //--------------------------------------------------------------------------------//
@@ -10,6 +11,7 @@ import (
 type Database struct {
 	Transport
 	scheme Scheme
+	clock  func() time.Time
 
 	mutex chan interface{}
 }
@@ -17,11 +19,77 @@ type Database struct {
 //--------------------------------------------------------------------------------//
 
 func (database *Database) RegisterTable(tableName string, tableStruct interface{}) (table *Table, err error) {
-	return database.scheme.RegisterTable(tableName, tableStruct)
+	table, err = database.scheme.RegisterTable(tableName, tableStruct)
+	if err != nil {
+		return
+	}
+
+	database.mutex <- true
+	if database.clock != nil && table.clock == nil {
+		table.clock = database.clock
+	}
+	<-database.mutex
+
+	return
+}
+
+func (database *Database) SetClock(clock func() time.Time) {
+	database.mutex <- true
+	database.clock = clock
+
+	if scheme, ok := database.scheme.(SchemeWithMigration); ok {
+		for _, table := range scheme.GetTableArray() {
+			table.clock = clock
+		}
+	}
+	<-database.mutex
+}
+
+func (database *Database) MigrateTo(version int64) error {
+	scheme, ok := database.scheme.(SchemeWithMigration)
+	if !ok {
+		return ErrorSchemeHasUnsupportedMigration
+	}
+
+	return scheme.MigrateTo(version)
+}
+
+func (database *Database) DumpSchema(writer io.Writer, sqlDialect string) error {
+	scheme, ok := database.scheme.(SchemeWithMigration)
+	if !ok {
+		return ErrorSchemeHasUnsupportedMigration
+	}
+
+	return SchemeHelperDumpSchema(writer, sqlDialect, scheme.GetTableArray())
+}
+
+func (database *Database) Verify(tableArray ...*Table) (*SchemeVerify, error) {
+	scheme, ok := database.scheme.(SchemeWithMigration)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedMigration
+	}
+
+	return scheme.Verify(tableArray...)
 }
 
 //--------------------------------------------------------------------------------//
 
+func (database *Database) Query(request BuilderWithResponse) (response interface{}, err error) {
+	response, err = database.Transport.Query(request)
+	if err != nil {
+		return
+	}
+
+	switch v := request.(type) {
+	case *BuilderSelect:
+		if len(v.preloadArray) > 0 {
+			err = helperRelationPreload(database.Transport.Query, v.selectTable, response, v.preloadArray)
+		}
+	}
+
+	return
+}
+
 func (database *Database) QueryValue(request BuilderWithResponse) (response interface{}, err error) {
 	var (
 		responseArray             interface{}
@@ -59,24 +127,12 @@ func (database *Database) ExecuteCreateTable(createTable *Table) error {
 	return database.Execute(NewBuilderCreate(createTable).IfNotExists(false))
 }
 
-func (database *Database) ExecuteDropTable(dropTable *Table) (err error) {
+func (database *Database) ExecuteDropTable(dropTable *Table) error {
 	if dropTable == nil {
 		return ErrorTableIsNil
 	}
 
-	sqlDb := database.LockSqlDb()
-	defer database.Unlock()
-
-	if sqlDb == nil {
-		return ErrorTransportIsAlreadyClosed
-	}
-
-	_, err = sqlDb.Exec(fmt.Sprintf("DROP TABLE `%s`", dropTable.GetSqlName()))
-	if err != nil {
-		return
-	}
-
-	return
+	return database.Execute(NewBuilderDrop(dropTable).IfExists(false))
 }
 
 func (database *Database) ExecuteDeleteTable(deleteTable *Table) error {
@@ -96,23 +152,7 @@ func (database *Database) QueryTableIndexLast(table *Table) (indexLast int64, er
 		return
 	}
 
-	sqlDb := database.LockSqlDb()
-	defer database.Unlock()
-
-	if sqlDb == nil {
-		err = ErrorTransportIsAlreadyClosed
-		return
-	}
-
-	dbRow := sqlDb.QueryRow(fmt.Sprintf("SELECT MAX(`%s`) FROM `%s`", table.GetAutoIncrement().GetSqlName(), table.GetSqlName()))
-
-	err = dbRow.Err()
-	if err != nil {
-		return
-	}
-
-	dbRow.Scan(&indexLast)
-	return
+	return helperQueryTableIndex(database.Transport, table, "MAX")
 }
 
 func (database *Database) QueryTableIndexCount(table *Table) (indexLast int64, err error) {
@@ -126,23 +166,7 @@ func (database *Database) QueryTableIndexCount(table *Table) (indexLast int64, e
 		return
 	}
 
-	sqlDb := database.LockSqlDb()
-	defer database.Unlock()
-
-	if sqlDb == nil {
-		err = ErrorTransportIsAlreadyClosed
-		return
-	}
-
-	dbRow := sqlDb.QueryRow(fmt.Sprintf("SELECT COUNT(`%s`) FROM `%s`", table.GetAutoIncrement().GetSqlName(), table.GetSqlName()))
-
-	err = dbRow.Err()
-	if err != nil {
-		return
-	}
-
-	dbRow.Scan(&indexLast)
-	return
+	return helperQueryTableIndex(database.Transport, table, "COUNT")
 }
 
 //--------------------------------------------------------------------------------//
@@ -188,6 +212,13 @@ func NewDatabase(transport Transport, scheme Scheme) (*Database, error) {
 		return nil, err
 	}
 
+	if scheme, ok := database.scheme.(SchemeWithMigration); ok {
+		err = scheme.Migrate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return database, nil
 }
 
@@ -5,16 +5,36 @@ import "fmt"
 //--------------------------------------------------------------------------------//
 
 var (
-	ErrorTableFieldMustHaveATable    = fmt.Errorf("table: field must have a table")
-	ErrorTableReferenceIsNil         = fmt.Errorf("table: reference is nil")
-	ErrorTableReferenceIsUnsupported = fmt.Errorf("table: reference is unsupported")
-	ErrorTableReferenceIsUncorrected = fmt.Errorf("table: reference is uncorrected")
+	ErrorTableFieldMustHaveATable       = fmt.Errorf("table: field must have a table")
+	ErrorTableFieldHasInvalidReference  = fmt.Errorf("table: field has invalid reference")
+	ErrorTableFieldHasInvalidIndex      = fmt.Errorf("table: field has invalid index")
+	ErrorTableFieldHasInvalidRelation   = fmt.Errorf("table: field has invalid relation")
+	ErrorTableFieldHasInvalidJson       = fmt.Errorf("table: field has invalid json")
+	ErrorTableFieldHasInvalidTime       = fmt.Errorf("table: field has invalid time")
+	ErrorTableFieldHasInvalidSoftDelete = fmt.Errorf("table: field has invalid soft delete")
+	ErrorTableFieldHasInvalidVersion    = fmt.Errorf("table: field has invalid version")
+	ErrorTableFieldHasDuplicateName     = fmt.Errorf("table: field has duplicate name")
+	ErrorTableHasUnknownRelation        = fmt.Errorf("table: has unknown relation")
+	ErrorTableReferenceIsNil            = fmt.Errorf("table: reference is nil")
+	ErrorTableReferenceIsUnsupported    = fmt.Errorf("table: reference is unsupported")
+	ErrorTableReferenceIsUncorrected    = fmt.Errorf("table: reference is uncorrected")
 )
 
 var (
 	ErrorBuilderMustHaveATable                = fmt.Errorf("builder: must have a table")
 	ErrorBuilderMustHaveATableWithName        = fmt.Errorf("builder: must have a table with name")
 	ErroroBuilderTableHasUnsupportedReferense = fmt.Errorf("builder: table has is unsupported reference")
+	ErrorBuilderMustHaveAField                = fmt.Errorf("builder: must have a field")
+	ErrorBuilderValueHasUnsupportedType       = fmt.Errorf("builder: value has unsupported type")
+	ErrorBuilderMustHaveAnAction              = fmt.Errorf("builder: must have an action")
+	ErrorBuilderHasUnsupportedDialect         = fmt.Errorf("builder: has unsupported action for dialect")
+)
+
+var (
+	ErrorConditionIsNil                  = fmt.Errorf("condition: is nil")
+	ErrorConditionMustHaveAField         = fmt.Errorf("condition: must have a field")
+	ErrorConditionHasUnsupportedValue    = fmt.Errorf("condition: has unsupported value")
+	ErrorConditionHasUnsupportedOperator = fmt.Errorf("condition: has unsupported operator")
 )
 
 var (
@@ -22,6 +42,7 @@ var (
 
 	ErrorTableIsNil                 = fmt.Errorf("table: is nil")
 	ErrorTableMustHaveAutoincrement = fmt.Errorf("table: must have AUTO_INCREMENT")
+	ErrorTableMustHavePrimaryKey    = fmt.Errorf("table: must have PRIMARY_KEY")
 
 	ErrorBuilderIsNil           = fmt.Errorf("builder: is nil")
 	ErrorBuilderWithoutResponse = fmt.Errorf("builder: without response")
@@ -37,12 +58,35 @@ var (
 	ErrorTransportIsAlreadyClosed  = fmt.Errorf("transport: is already closed")
 	ErrorTransportMustHaveDatabase = fmt.Errorf("transport: must have database")
 
+	ErrorTransportMemoryHasUnknownTable       = fmt.Errorf("transport: memory has unknown table")
+	ErrorTransportMemoryHasUnknownField       = fmt.Errorf("transport: memory has unknown field")
+	ErrorTransportMemoryHasTableAlready       = fmt.Errorf("transport: memory has table already")
+	ErrorTransportMemoryHasUnsupportedBuilder = fmt.Errorf("transport: memory has unsupported builder")
+	ErrorTransportMemoryHasUnsupportedWhere   = fmt.Errorf("transport: memory has unsupported raw where or set")
+	ErrorTransportMemoryHasUnsupportedValue   = fmt.Errorf("transport: memory has unsupported value")
+	ErrorTransportMemoryViolatesPrimaryKey    = fmt.Errorf("transport: memory violates PRIMARY_KEY constraint")
+	ErrorTransportMemoryViolatesNotNull       = fmt.Errorf("transport: memory violates NOT_NULL constraint")
+	ErrorTransportMemoryViolatesUniqueGroup   = fmt.Errorf("transport: memory violates UNIQUE_GROUP constraint")
+
+	ErrorTransportShardedHasMixedDialect = fmt.Errorf("transport: sharded has mixed dialects")
+
+	ErrorTransportReplayHasDrift              = fmt.Errorf("transport: replay has drift from the recording")
+	ErrorTransportReplayHasUnsupportedFixture = fmt.Errorf("transport: replay has unsupported fixture")
+
 	ErrorTransactionIsAlreadyOpened = fmt.Errorf("transaction: is already opened")
 	ErrorTransactionIsAlreadyClosed = fmt.Errorf("transaction: is already closed")
 
+	ErrorTransactionViolatesReference = fmt.Errorf("transaction: violates REFERENCES constraint")
+
+	ErrorOptimisticLockConflict = fmt.Errorf("transaction: optimistic lock conflict")
+
 	ErrorSchemeIsNil            = fmt.Errorf("scheme: is nil")
 	ErrorSchemeMustHaveTable    = fmt.Errorf("scheme: must have table")
 	ErrorSchemeMustHaveDatabase = fmt.Errorf("scheme: must have database")
+	ErrorSchemeMustHaveStorage  = fmt.Errorf("scheme: must have storage")
+
+	ErrorSchemeStorageIsReadOnly = fmt.Errorf("scheme: storage is read-only")
+	ErrorSchemeStorageIsTampered = fmt.Errorf("scheme: storage is tampered")
 
 	ErrorSchemeTransactionIsAlreadyOpened = fmt.Errorf("scheme: transaction is already opened")
 	ErrorSchemeTransactionIsAlreadyClosed = fmt.Errorf("scheme: transaction is already closed")
@@ -50,5 +94,15 @@ var (
 
 	ErrorSchemeHasUnsupportedStruct        = fmt.Errorf("scheme: has unsupported struct")
 	ErrorSchemeHasUnsupportedHeader        = fmt.Errorf("scheme: has unsupported header")
+	ErrorSchemeHasUnsupportedMigration     = fmt.Errorf("scheme: has unsupported migration")
 	ErrorSchemeMigrationIsLimitedByVersion = fmt.Errorf("scheme: migration is limited by version")
+	ErrorSchemeMigrationIsDuplicated       = fmt.Errorf("scheme: migration is duplicated")
+	ErrorSchemeMigrationIsTampered         = fmt.Errorf("scheme: migration is tampered")
+	ErrorSchemeMigrationIsIrreversible     = fmt.Errorf("scheme: migration is irreversible")
+
+	ErrorSchemeMigrationDropsPopulatedColumn = fmt.Errorf("scheme: migration drops populated column")
+
+	ErrorSchemeIntrospectHasUnsupportedDialect = fmt.Errorf("scheme: introspect has unsupported dialect")
+
+	ErrorSchemeLockIsTimedOut = fmt.Errorf("scheme: lock is timed out")
 )
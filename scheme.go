@@ -4,6 +4,10 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 //--------------------------------------------------------------------------------//
@@ -18,8 +22,43 @@ type Scheme interface {
 	RegisterTable(string, interface{}) (*Table, error)
 }
 
+type SchemeWithMigration interface {
+	Scheme
+
+	AllowColumnDrop(bool)
+
+	RegisterMigration(*SchemeMigrationRegistry) error
+	Migrate() error
+	MigrateTo(int64) error
+
+	Plan(...*Table) (*SchemePlan, error)
+	Verify(...*Table) (*SchemeVerify, error)
+
+	GetTableArray() []*Table
+	GetStorage() SchemeStorage
+	GetStorageTableArray() []*SchemeStorageTable
+	History() ([]SchemeHistoryStable, error)
+}
+
+type SchemeStorage interface {
+	GetName() string
+	GetTableNameArray() []string
+	IsReadOnly() bool
+
+	Import(*Transaction) (map[string]*SchemeStorageTable, error)
+	Export(*Transaction, *SchemeStorageTable) error
+	Delete(*Transaction, string) error
+
+	Commit() error
+	Rollback() error
+}
+
 //--------------------------------------------------------------------------------//
 
+type SchemeStorageHeader struct {
+	SchemeHeader string `sql:"NAME=scheme_header"`
+}
+
 type SchemeStorageV1 struct {
 	SchemeHeader  string `sql:"NAME=scheme_header | NOT_NULL" json:"-"`
 	SchemeVersion int64  `sql:"NAME=scheme_version | NOT_NULL" json:"-"`
@@ -42,7 +81,105 @@ type SchemeStorageV1 struct {
 	FieldValueCheck      *string `sql:"NAME=field_value_check"`
 }
 
-type SchemeStorageStable SchemeStorageV1
+// SchemeStorageV2 keeps the V1 field record and adds the table and index records.
+type SchemeStorageV2 SchemeStorageV1
+
+type SchemeStorageTableV2 struct {
+	SchemeHeader  string `sql:"NAME=scheme_header | NOT_NULL"`
+	SchemeVersion int64  `sql:"NAME=scheme_version | NOT_NULL"`
+
+	LocalTableName  string `sql:"NAME=local_table_name"`
+	RemoteTableName string `sql:"NAME=remote_table_name | TYPE=VARCHAR(256) | PRIMARY_KEY"`
+
+	TableHash        string  `sql:"NAME=table_hash | NOT_NULL"`
+	TableOption      *string `sql:"NAME=table_option"`
+	MigrationVersion int64   `sql:"NAME=migration_version | NOT_NULL"`
+	UpdatedAt        int64   `sql:"NAME=updated_at | NOT_NULL"`
+}
+
+type SchemeStorageIndexV2 struct {
+	SchemeHeader  string `sql:"NAME=scheme_header | NOT_NULL" json:"-"`
+	SchemeVersion int64  `sql:"NAME=scheme_version | NOT_NULL" json:"-"`
+
+	RemoteTableName string `sql:"NAME=remote_table_name | TYPE=VARCHAR(256) | PRIMARY_KEY"`
+	IndexName       string `sql:"NAME=index_name | TYPE=VARCHAR(256) | PRIMARY_KEY"`
+	IndexKind       string `sql:"NAME=index_kind | TYPE=VARCHAR(16) | NOT_NULL"`
+	IndexColumn     string `sql:"NAME=index_column | NOT_NULL"`
+	IndexIsUnique   bool   `sql:"NAME=index_is_unique"`
+
+	IndexWhere *string `sql:"NAME=index_where" json:",omitempty"`
+
+	ReferenceTable    *string `sql:"NAME=reference_table" json:",omitempty"`
+	ReferenceColumn   *string `sql:"NAME=reference_column" json:",omitempty"`
+	ReferenceOnDelete *string `sql:"NAME=reference_on_delete" json:",omitempty"`
+	ReferenceOnUpdate *string `sql:"NAME=reference_on_update" json:",omitempty"`
+}
+
+type SchemeStorageStable SchemeStorageV2
+type SchemeStorageTableStable SchemeStorageTableV2
+type SchemeStorageIndexStable SchemeStorageIndexV2
+
+const (
+	SchemeHeaderV1     = "V1"
+	SchemeHeaderV2     = "V2"
+	SchemeHeaderStable = SchemeHeaderV2
+)
+
+const (
+	SchemeIndexKindIndex      = "index"
+	SchemeIndexKindForeignKey = "foreign_key"
+)
+
+const (
+	SchemeHistoryKindStep  = "step"
+	SchemeHistoryKindTable = "table"
+)
+
+type SchemeHistoryV1 struct {
+	HistoryVersion   int64   `sql:"NAME=history_version | PRIMARY_KEY"`
+	HistoryKind      string  `sql:"NAME=history_kind | TYPE=VARCHAR(16) | PRIMARY_KEY"`
+	HistoryName      string  `sql:"NAME=history_name | TYPE=VARCHAR(256) | PRIMARY_KEY"`
+	HistoryChecksum  string  `sql:"NAME=history_checksum | NOT_NULL"`
+	HistorySnapshot  *string `sql:"NAME=history_snapshot"`
+	HistoryAppliedAt int64   `sql:"NAME=history_applied_at | NOT_NULL"`
+}
+
+type SchemeHistoryStable SchemeHistoryV1
+
+type schemeSnapshotField struct {
+	SchemeHeader  string
+	SchemeVersion int64
+
+	LocalTableName  string
+	RemoteTableName string
+
+	LocalFieldName  string
+	RemoteFieldName string
+
+	LocalFieldType  string
+	RemoteFieldType string
+
+	FieldIndex           int
+	FieldIsPrimaryKey    bool
+	FieldIsAutoIncrement bool
+	FieldIsNotNull       bool
+	FieldInUniqueGroup   *string
+	FieldValueDefault    *string
+	FieldValueCheck      *string
+}
+
+type schemeSnapshotTable struct {
+	SchemeHeader  string
+	SchemeVersion int64
+
+	LocalTableName  string
+	RemoteTableName string
+
+	FieldArray []schemeSnapshotField
+	IndexArray []SchemeStorageIndexStable `json:",omitempty"`
+
+	TableOption *string `json:",omitempty"`
+}
 
 type SchemeStorageTable struct {
 	SchemeHeader  string
@@ -52,6 +189,11 @@ type SchemeStorageTable struct {
 	RemoteTableName string
 
 	FieldMap map[string]*SchemeStorageStable
+	IndexMap map[string]*SchemeStorageIndexStable `json:",omitempty"`
+
+	TableOption      *string `json:",omitempty"`
+	MigrationVersion int64   `json:"-"`
+	UpdatedAt        int64   `json:"-"`
 }
 
 //--------------------------------------------------------------------------------//
@@ -87,6 +229,7 @@ func SchemeHelperTableToFieldMap(schemeHeader string, schemeVersion int64, table
 		RemoteTableName: table.GetSqlName(),
 
 		FieldMap: map[string]*SchemeStorageStable{},
+		IndexMap: map[string]*SchemeStorageIndexStable{},
 	}
 
 	if table == nil {
@@ -118,7 +261,300 @@ func SchemeHelperTableToFieldMap(schemeHeader string, schemeVersion int64, table
 			FieldValueCheck:      tableField.ValueCheck(),
 		}
 	}
+
+	for _, indexGroup := range table.GetIndexNameArray() {
+		indexColumnArray := []string{}
+
+		for _, tableField := range table.GetIndexArray(indexGroup) {
+			if tableField.IsIndexDesc() {
+				indexColumnArray = append(indexColumnArray, fmt.Sprintf("%s DESC", tableField.GetSqlName()))
+			} else {
+				indexColumnArray = append(indexColumnArray, tableField.GetSqlName())
+			}
+		}
+
+		indexName := helperBuilderIndexName(table.GetSqlName(), indexGroup)
+
+		storageTable.IndexMap[indexName] = &SchemeStorageIndexStable{
+			SchemeHeader:  schemeHeader,
+			SchemeVersion: schemeVersion,
+
+			RemoteTableName: table.GetSqlName(),
+			IndexName:       indexName,
+			IndexKind:       SchemeIndexKindIndex,
+			IndexColumn:     strings.Join(indexColumnArray, ","),
+			IndexWhere:      table.GetIndexWhere(indexGroup),
+		}
+	}
+
+	for _, tableField := range table.GetReferenceArray() {
+		indexName := fmt.Sprintf("%s_%s_fk", table.GetSqlName(), tableField.GetSqlName())
+
+		storageTable.IndexMap[indexName] = &SchemeStorageIndexStable{
+			SchemeHeader:  schemeHeader,
+			SchemeVersion: schemeVersion,
+
+			RemoteTableName: table.GetSqlName(),
+			IndexName:       indexName,
+			IndexKind:       SchemeIndexKindForeignKey,
+			IndexColumn:     tableField.GetSqlName(),
+
+			ReferenceTable:    tableField.ReferenceTable(),
+			ReferenceColumn:   tableField.ReferenceColumn(),
+			ReferenceOnDelete: tableField.ReferenceOnDelete(),
+			ReferenceOnUpdate: tableField.ReferenceOnUpdate(),
+		}
+	}
+
 	return &storageTable, nil
 }
 
 //--------------------------------------------------------------------------------//
+
+func SchemeHelperFieldMapToSnapshot(storageTable *SchemeStorageTable) (string, error) {
+	if storageTable == nil {
+		return "", ErrorTableIsNil
+	}
+
+	snapshotJson, err := json.Marshal(schemeHelperFieldMapToSnapshotTable(storageTable))
+	if err != nil {
+		return "", err
+	}
+
+	return string(snapshotJson), nil
+}
+
+func SchemeHelperSnapshotToFieldMap(snapshot string) (*SchemeStorageTable, error) {
+	var snapshotTable schemeSnapshotTable
+
+	err := json.Unmarshal([]byte(snapshot), &snapshotTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemeHelperSnapshotTableToFieldMap(snapshotTable), nil
+}
+
+func schemeHelperFieldMapToSnapshotTable(storageTable *SchemeStorageTable) schemeSnapshotTable {
+	snapshotTable := schemeSnapshotTable{
+		SchemeHeader:  storageTable.SchemeHeader,
+		SchemeVersion: storageTable.SchemeVersion,
+
+		LocalTableName:  storageTable.LocalTableName,
+		RemoteTableName: storageTable.RemoteTableName,
+
+		TableOption: storageTable.TableOption,
+	}
+
+	for _, fieldUnit := range storageTable.FieldMap {
+		snapshotTable.FieldArray = append(snapshotTable.FieldArray, schemeSnapshotField(*fieldUnit))
+	}
+
+	sort.Slice(snapshotTable.FieldArray, func(i, j int) bool {
+		return snapshotTable.FieldArray[i].FieldIndex < snapshotTable.FieldArray[j].FieldIndex
+	})
+
+	for _, indexUnit := range storageTable.IndexMap {
+		snapshotTable.IndexArray = append(snapshotTable.IndexArray, *indexUnit)
+	}
+
+	sort.Slice(snapshotTable.IndexArray, func(i, j int) bool {
+		return snapshotTable.IndexArray[i].IndexName < snapshotTable.IndexArray[j].IndexName
+	})
+
+	return snapshotTable
+}
+
+func schemeHelperSnapshotTableToFieldMap(snapshotTable schemeSnapshotTable) *SchemeStorageTable {
+	storageTable := SchemeStorageTable{
+		SchemeHeader:  snapshotTable.SchemeHeader,
+		SchemeVersion: snapshotTable.SchemeVersion,
+
+		LocalTableName:  snapshotTable.LocalTableName,
+		RemoteTableName: snapshotTable.RemoteTableName,
+
+		FieldMap: map[string]*SchemeStorageStable{},
+		IndexMap: map[string]*SchemeStorageIndexStable{},
+
+		TableOption: snapshotTable.TableOption,
+	}
+
+	for _, fieldUnit := range snapshotTable.FieldArray {
+		storageField := SchemeStorageStable(fieldUnit)
+		storageTable.FieldMap[storageField.RemoteFieldName] = &storageField
+	}
+
+	for _, indexUnit := range snapshotTable.IndexArray {
+		storageIndex := indexUnit
+		storageTable.IndexMap[storageIndex.IndexName] = &storageIndex
+	}
+
+	return &storageTable
+}
+
+func SchemeHelperFieldMapToTable(storageTable *SchemeStorageTable) (*Table, error) {
+	var (
+		fieldArray       []*SchemeStorageStable
+		structFieldArray []reflect.StructField
+	)
+
+	if storageTable == nil || len(storageTable.FieldMap) == 0 {
+		return nil, ErrorTableIsNil
+	}
+
+	for _, fieldUnit := range storageTable.FieldMap {
+		fieldArray = append(fieldArray, fieldUnit)
+	}
+
+	sort.Slice(fieldArray, func(i, j int) bool {
+		return fieldArray[i].FieldIndex < fieldArray[j].FieldIndex
+	})
+
+	for fieldIndex, fieldUnit := range fieldArray {
+		structFieldArray = append(structFieldArray, reflect.StructField{
+			Name: fmt.Sprintf("Field%d", fieldIndex),
+			Type: schemeHelperFieldType(fieldUnit),
+		})
+	}
+
+	tableReflectType := reflect.StructOf(structFieldArray)
+
+	table := &Table{
+		goName:            storageTable.LocalTableName,
+		goType:            tableReflectType,
+		sqlName:           storageTable.RemoteTableName,
+		goFieldNameArray:  []string{},
+		goFieldMap:        map[string]*TableField{},
+		sqlFieldNameArray: []string{},
+		sqlFieldMap:       map[string]*TableField{},
+		goPrimaryKeyArray: []*TableField{},
+		goAutoIncrement:   nil,
+		goUniqueMap:       map[string][]*TableField{},
+		goIndexMap:        map[string][]*TableField{},
+		goSoftDelete:      nil,
+		goVersion:         nil,
+
+		goRelationNameArray: []string{},
+		goRelationMap:       map[string]*TableRelation{},
+
+		clock: nil,
+	}
+
+	for fieldIndex, fieldUnit := range fieldArray {
+		reflectStructField := tableReflectType.Field(fieldIndex)
+
+		field := &TableField{
+			goIndex: fieldIndex,
+			goName:  reflectStructField.Name,
+			goField: reflectStructField,
+			goType:  reflectStructField.Type.Kind(),
+
+			sqlName: fieldUnit.RemoteFieldName,
+			sqlType: fieldUnit.RemoteFieldType,
+
+			isPrimaryKey:    fieldUnit.FieldIsPrimaryKey,
+			isAutoIncrement: fieldUnit.FieldIsAutoIncrement,
+			isNotNull:       fieldUnit.FieldIsNotNull,
+
+			inUniqueGroup: fieldUnit.FieldInUniqueGroup,
+
+			valueDefault: fieldUnit.FieldValueDefault,
+			valueCheck:   fieldUnit.FieldValueCheck,
+		}
+
+		table.goFieldNameArray = append(table.goFieldNameArray, field.goName)
+		table.goFieldMap[field.goName] = field
+
+		table.sqlFieldNameArray = append(table.sqlFieldNameArray, field.sqlName)
+		table.sqlFieldMap[field.sqlName] = field
+
+		if field.isPrimaryKey {
+			table.goPrimaryKeyArray = append(table.goPrimaryKeyArray, field)
+		}
+
+		if field.isAutoIncrement {
+			table.goAutoIncrement = field
+		}
+
+		if field.inUniqueGroup != nil {
+			table.goUniqueMap[*field.inUniqueGroup] = append(table.goUniqueMap[*field.inUniqueGroup], field)
+		}
+	}
+
+	for _, indexUnit := range storageTable.IndexMap {
+		switch indexUnit.IndexKind {
+		case SchemeIndexKindIndex:
+			indexGroup := schemeHelperIndexGroup(storageTable.RemoteTableName, indexUnit.IndexName)
+
+			for columnIndex, indexColumn := range strings.Split(indexUnit.IndexColumn, ",") {
+				fieldName, fieldDesc := strings.CutSuffix(strings.TrimSpace(indexColumn), " DESC")
+
+				if field := table.sqlFieldMap[fieldName]; field != nil {
+					field.inIndexGroup = &indexGroup
+					field.isIndexDesc = fieldDesc
+
+					if columnIndex == 0 {
+						field.indexWhere = indexUnit.IndexWhere
+					}
+
+					table.goIndexMap[indexGroup] = append(table.goIndexMap[indexGroup], field)
+				}
+			}
+		case SchemeIndexKindForeignKey:
+			if field := table.sqlFieldMap[indexUnit.IndexColumn]; field != nil {
+				field.referenceTable = indexUnit.ReferenceTable
+				field.referenceColumn = indexUnit.ReferenceColumn
+				field.referenceOnDelete = indexUnit.ReferenceOnDelete
+				field.referenceOnUpdate = indexUnit.ReferenceOnUpdate
+			}
+		}
+	}
+
+	return table, nil
+}
+
+func schemeHelperFieldType(fieldUnit *SchemeStorageStable) reflect.Type {
+	switch fieldUnit.LocalFieldType {
+	case "bool":
+		return reflect.TypeOf(false)
+	case "int":
+		return reflect.TypeOf(int(0))
+	case "int8":
+		return reflect.TypeOf(int8(0))
+	case "int16":
+		return reflect.TypeOf(int16(0))
+	case "int32":
+		return reflect.TypeOf(int32(0))
+	case "int64":
+		return reflect.TypeOf(int64(0))
+	case "uint":
+		return reflect.TypeOf(uint(0))
+	case "uint8":
+		return reflect.TypeOf(uint8(0))
+	case "uint16":
+		return reflect.TypeOf(uint16(0))
+	case "uint32":
+		return reflect.TypeOf(uint32(0))
+	case "uint64":
+		return reflect.TypeOf(uint64(0))
+	case "float32":
+		return reflect.TypeOf(float32(0))
+	case "float64":
+		return reflect.TypeOf(float64(0))
+	case "string":
+		return reflect.TypeOf("")
+	}
+
+	sqlType := strings.ToUpper(fieldUnit.RemoteFieldType)
+
+	switch {
+	case strings.Contains(sqlType, "INT"):
+		return reflect.TypeOf((*int64)(nil))
+	case strings.Contains(sqlType, "REAL") || strings.Contains(sqlType, "FLOA") || strings.Contains(sqlType, "DOUB"):
+		return reflect.TypeOf((*float64)(nil))
+	default:
+		return reflect.TypeOf((*string)(nil))
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -1,9 +1,11 @@
 package sqlctrl
 
 import (
-	"database/sql"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 //--------------------------------------------------------------------------------//
@@ -14,16 +16,20 @@ type schemeDatabase struct {
 	transport        Transport
 	transactionCount int64
 	transaction      *Transaction
+	transactionLock  *schemeLock
 
 	mutex chan interface{}
 
-	storageName        string
-	storageVersion     int64
-	storageV1Table     *Table
-	storageStableTable *Table
-	storageRemote      map[string]*SchemeStorageTable
-	storageLocal       map[string]*SchemeStorageTable
-	tableMap           map[string]*Table
+	storage         SchemeStorage
+	storageVersion  int64
+	storageRemote   map[string]*SchemeStorageTable
+	storageLocal    map[string]*SchemeStorageTable
+	storageImported bool
+	tableMap        map[string]*Table
+
+	migrationRegistry *SchemeMigrationRegistry
+	historyTable      *Table
+	allowColumnDrop   bool
 }
 
 //--------------------------------------------------------------------------------//
@@ -35,12 +41,54 @@ func (scheme *schemeDatabase) transactionOpen() (transaction *Transaction, err e
 			return
 		}
 
-		transaction, err = scheme.database.TransactionOpen()
+		if scheme.transactionLock != nil {
+			err = scheme.transactionLock.Lock(scheme.transport)
+			if err != nil {
+				return
+			}
+		}
+
+		switch transport := scheme.transport.(type) {
+		case interface {
+			transactionOpenWithoutReference() (*Transaction, error)
+		}:
+			transaction, err = transport.transactionOpenWithoutReference()
+		default:
+			transaction, err = scheme.database.TransactionOpen()
+		}
+
 		if err != nil {
+			if scheme.transactionLock != nil {
+				scheme.transactionLock.Unlock(scheme.transport)
+			}
+
 			return
 		}
 
 		scheme.transaction = transaction
+		scheme.transactionCount++
+
+		if scheme.transactionLock != nil {
+			err = scheme.transactionLock.Renew(transaction)
+			if err != nil {
+				scheme.transactionClose(err)
+				return nil, err
+			}
+		}
+
+		if scheme.storageImported {
+			var storageRemote map[string]*SchemeStorageTable
+
+			storageRemote, err = scheme.storage.Import(transaction)
+			if err != nil {
+				scheme.transactionClose(err)
+				return nil, err
+			}
+
+			scheme.storageRemote = storageRemote
+		}
+
+		return
 	} else {
 		transaction = scheme.transaction
 	}
@@ -71,8 +119,20 @@ func (scheme *schemeDatabase) transactionClose(errIn error) (errOut error) {
 				errOut = scheme.transaction.Rollback()
 			}
 
-			if errOut == nil {
-				scheme.transaction = nil
+			if errIn == nil && errOut == nil {
+				errOut = scheme.storage.Commit()
+			} else {
+				scheme.storage.Rollback()
+			}
+
+			scheme.transaction = nil
+			scheme.transactionCount = 0
+
+			if scheme.transactionLock != nil {
+				errUnlock := scheme.transactionLock.Unlock(scheme.transport)
+				if errOut == nil {
+					errOut = errUnlock
+				}
 			}
 		}
 
@@ -92,8 +152,8 @@ func (scheme *schemeDatabase) SchemeRegister(database *Database) (err error) {
 		<-scheme.mutex
 	}()
 
-	if scheme.storageStableTable == nil {
-		return ErrorSchemeMustHaveTable
+	if scheme.storage == nil {
+		return ErrorSchemeMustHaveStorage
 	}
 
 	if database == nil && scheme.database == nil {
@@ -111,24 +171,45 @@ func (scheme *schemeDatabase) SchemeRegister(database *Database) (err error) {
 	if database != nil {
 		scheme.database = database
 		scheme.transport = database.Transport
+
+		scheme.transactionLock, err = newSchemeLock(scheme.storage.GetName(), scheme.transport.GetDialect())
 	}
 
 	return
 }
 
-func (scheme *schemeDatabase) ImportV1() (err error) {
+func (scheme *schemeDatabase) Import() (err error) {
 	var (
-		transaction       *Transaction
-		responseInterface interface{}
-		responseArray     []SchemeStorageV1
-		ok                bool
-		storageRemote     = make(map[string]*SchemeStorageTable)
+		transaction   *Transaction
+		storageRemote map[string]*SchemeStorageTable
 	)
 
-	if scheme.storageV1Table == nil {
-		return ErrorSchemeMustHaveTable
+	transaction, err = scheme.transactionOpen()
+	if err != nil {
+		return
 	}
 
+	defer func() {
+		err = scheme.transactionClose(err)
+	}()
+
+	storageRemote, err = scheme.storage.Import(transaction)
+	if err != nil {
+		return
+	}
+
+	scheme.storageRemote = storageRemote
+	scheme.storageImported = true
+
+	return
+}
+
+func (scheme *schemeDatabase) Migration(table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (err error) {
+	var (
+		transaction  *Transaction
+		builderArray []Builder
+	)
+
 	transaction, err = scheme.transactionOpen()
 	if err != nil {
 		return
@@ -138,196 +219,564 @@ func (scheme *schemeDatabase) ImportV1() (err error) {
 		err = scheme.transactionClose(err)
 	}()
 
-	responseInterface, err = transaction.Query(NewBuilderSelect(scheme.storageV1Table).Where("scheme_header = \"V1\""))
+	builderArray, err = SchemeHelperMigrationBuilderArray(scheme.transport.GetDialect(), table, fieldMapRemote, fieldMapLocal)
 	if err != nil {
 		return
 	}
 
-	responseArray, ok = responseInterface.([]SchemeStorageV1)
-	if !ok {
-		return ErrorSchemeHasUnsupportedStruct
-	}
+	if !scheme.allowColumnDrop {
+		for _, fieldDropName := range SchemeHelperMigrationFieldDrop(table, fieldMapRemote, fieldMapLocal) {
+			var populated bool
 
-	for _, storageField := range responseArray {
-		if storageRemote[storageField.RemoteTableName] == nil {
-			storageRemote[storageField.RemoteTableName] = &SchemeStorageTable{
-				LocalTableName:  storageField.LocalTableName,
-				RemoteTableName: storageField.RemoteTableName,
-				SchemeHeader:    storageField.SchemeHeader,
-				SchemeVersion:   storageField.SchemeVersion,
-				FieldMap:        map[string]*SchemeStorageStable{},
+			populated, err = scheme.migrationPopulated(transaction, fieldMapRemote, fieldDropName)
+			if err != nil {
+				return
 			}
-		}
 
-		storageRemote[storageField.RemoteTableName].FieldMap[storageField.RemoteFieldName] = &SchemeStorageStable{
-			SchemeHeader:         storageField.SchemeHeader,
-			SchemeVersion:        storageField.SchemeVersion,
-			LocalTableName:       storageField.LocalTableName,
-			RemoteTableName:      storageField.RemoteTableName,
-			LocalFieldName:       storageField.LocalFieldName,
-			RemoteFieldName:      storageField.RemoteFieldName,
-			LocalFieldType:       storageField.LocalFieldType,
-			RemoteFieldType:      storageField.RemoteFieldType,
-			FieldIndex:           storageField.FieldIndex,
-			FieldIsPrimaryKey:    storageField.FieldIsPrimaryKey,
-			FieldIsAutoIncrement: storageField.FieldIsAutoIncrement,
-			FieldIsNotNull:       storageField.FieldIsNotNull,
-			FieldInUniqueGroup:   storageField.FieldInUniqueGroup,
-			FieldValueDefault:    storageField.FieldValueDefault,
-			FieldValueCheck:      storageField.FieldValueCheck,
+			if populated {
+				return fmt.Errorf("%w: %s.%s", ErrorSchemeMigrationDropsPopulatedColumn, fieldMapRemote.RemoteTableName, fieldDropName)
+			}
 		}
 	}
 
-	scheme.storageRemote = storageRemote
+	for _, builderUnit := range builderArray {
+		err = transaction.Execute(builderUnit)
+		if err != nil {
+			return
+		}
+	}
 
 	return
 }
 
-func (scheme *schemeDatabase) Import() (err error) {
+func (scheme *schemeDatabase) Export() (err error) {
 	var (
-		transaction        *Transaction
-		sqlTx              *sql.Tx
-		sqlRowArray        *sql.Rows
-		storageHeaderArray []string
+		transaction      *Transaction
+		fieldMapRemote   *SchemeStorageTable
+		migrationVersion int64
+		updatedAt        = time.Now().Unix()
+		ok               bool
 	)
 
+	if len(scheme.storageLocal) > 0 && scheme.storage.IsReadOnly() {
+		return ErrorSchemeStorageIsReadOnly
+	}
+
 	transaction, err = scheme.transactionOpen()
 	if err != nil {
 		return
 	}
 
 	defer func() {
+		err = scheme.transactionClose(err)
+
+		if err == nil {
+			if len(scheme.storageRemote) == 0 {
+				scheme.storageRemote = scheme.storageLocal
+			} else {
+				for _, tableUnit := range scheme.storageLocal {
+					scheme.storageRemote[tableUnit.RemoteTableName] = tableUnit
+				}
+			}
+
+			scheme.storageLocal = make(map[string]*SchemeStorageTable)
+		}
+	}()
+
+	if scheme.historyTable != nil {
+		migrationVersion, err = scheme.historyStepVersion(transaction)
 		if err != nil {
-			scheme.storageRemote = nil
+			return
+		}
+	}
 
-			if transaction.ExecuteDropTable(scheme.storageStableTable) == nil {
-				err = scheme.Export()
+	tableArray := []*Table{}
+	tableNameMap := map[*Table]string{}
+
+	for tableName := range scheme.storageLocal {
+		tableArray = append(tableArray, scheme.tableMap[tableName])
+		tableNameMap[scheme.tableMap[tableName]] = tableName
+	}
+
+	for _, table := range SchemeHelperTableOrder(tableArray) {
+		tableName := tableNameMap[table]
+		fieldMapLocal := scheme.storageLocal[tableName]
+
+		fieldMapRemote, ok = scheme.storageRemote[fieldMapLocal.RemoteTableName]
+		if ok {
+			err = scheme.Migration(scheme.tableMap[tableName], fieldMapRemote, fieldMapLocal)
+		} else {
+			for _, builderUnit := range SchemeHelperCreateBuilderArray(scheme.tableMap[tableName]) {
+				err = transaction.Execute(builderUnit)
+				if err != nil {
+					break
+				}
 			}
 		}
 
+		if err != nil {
+			return
+		}
+
+		schemeHelperExportPrepare(fieldMapLocal, migrationVersion, updatedAt)
+
+		err = scheme.storage.Export(transaction, fieldMapLocal)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (scheme *schemeDatabase) RegisterTable(tableName string, tableStruct interface{}) (*Table, error) {
+	return scheme.registerTable(tableName, tableStruct, scheme.storageVersion)
+}
+
+func (scheme *schemeDatabase) registerTable(tableName string, tableStruct interface{}, tableVersion int64) (table *Table, err error) {
+	var (
+		fieldMapLocal  *SchemeStorageTable
+		fieldMapRemote *SchemeStorageTable
+		needExport     bool
+	)
+
+	table, err = NewTable(tableName, tableStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldMapLocal, err = SchemeHelperTableToFieldMap(SchemeHeaderStable, tableVersion, table)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scheme.transactionOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
 		err = scheme.transactionClose(err)
 	}()
 
-	sqlTx = scheme.database.LockSqlTx()
-	scheme.database.Unlock()
+	fieldMapRemote = scheme.storageRemote[tableName]
 
-	sqlRowArray, err = sqlTx.Query(fmt.Sprintf("SELECT `scheme_header` FROM `%s` GROUP BY `scheme_header`", scheme.storageName))
+	needExport, err = SchemeHelperNeedExport(fieldMapRemote, fieldMapLocal)
 	if err != nil {
-		return
+		return nil, err
+	}
+
+	if needExport && scheme.storage.IsReadOnly() {
+		return nil, fmt.Errorf("%w: %s", ErrorSchemeStorageIsReadOnly, tableName)
 	}
 
-	for sqlRowArray.Next() {
-		var storageHeader string
-		err = sqlRowArray.Scan(&storageHeader)
+	scheme.tableMap[tableName] = table
+
+	if needExport {
+		scheme.storageLocal[tableName] = fieldMapLocal
+
+		err = scheme.exportHistory(tableName, fieldMapRemote, fieldMapLocal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
+
+func (scheme *schemeDatabase) AllowColumnDrop(allowColumnDrop bool) {
+	scheme.mutex <- true
+	scheme.allowColumnDrop = allowColumnDrop
+	<-scheme.mutex
+}
+
+func (scheme *schemeDatabase) RegisterMigration(migrationRegistry *SchemeMigrationRegistry) error {
+	if migrationRegistry == nil {
+		return ErrorGenericInvalidArgument
+	}
+
+	scheme.mutex <- true
+	scheme.migrationRegistry = migrationRegistry
+	<-scheme.mutex
+
+	return nil
+}
+
+func (scheme *schemeDatabase) Migrate() (err error) {
+	var (
+		transaction  *Transaction
+		historyArray []SchemeHistoryStable
+		historyMap   = make(map[int64]SchemeHistoryStable)
+	)
+
+	if scheme.historyTable == nil && (scheme.migrationRegistry != nil || !scheme.storage.IsReadOnly()) {
+		scheme.historyTable, err = scheme.registerTable(fmt.Sprintf("%s_history", scheme.storage.GetName()), SchemeHistoryStable{}, 1)
 		if err != nil {
 			return
 		}
+	}
 
-		storageHeaderArray = append(storageHeaderArray, storageHeader)
+	if scheme.migrationRegistry == nil {
+		return
 	}
 
-	for _, storageHeader := range storageHeaderArray {
-		switch storageHeader {
-		case "V1":
-			err = scheme.ImportV1()
-		default:
-			err = ErrorSchemeHasUnsupportedHeader
+	transaction, err = scheme.transactionOpen()
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		err = scheme.transactionClose(err)
+	}()
+
+	historyArray, err = scheme.historyQuery(transaction)
+	if err != nil {
+		return
+	}
+
+	for _, historyUnit := range historyArray {
+		if historyUnit.HistoryKind == SchemeHistoryKindStep {
+			historyMap[historyUnit.HistoryVersion] = historyUnit
+		}
+	}
+
+	for _, migration := range scheme.migrationRegistry.GetMigrationArray() {
+		historyUnit, applied := historyMap[migration.Version]
+		if applied {
+			if historyUnit.HistoryChecksum != migration.Checksum {
+				return fmt.Errorf("%w: version %d (%s)", ErrorSchemeMigrationIsTampered, migration.Version, migration.Name)
+			}
+
+			continue
+		}
+
+		err = migration.Up(transaction)
+		if err != nil {
+			return fmt.Errorf("scheme: migration version %d (%s): %w", migration.Version, migration.Name, err)
+		}
+
+		err = scheme.historyAppend(transaction, SchemeHistoryStable{
+			HistoryVersion:  migration.Version,
+			HistoryKind:     SchemeHistoryKindStep,
+			HistoryName:     migration.Name,
+			HistoryChecksum: migration.Checksum,
+			HistorySnapshot: migration.DownSql,
+		})
+		if err != nil {
+			return
 		}
 	}
 
 	return
 }
 
-func (scheme *schemeDatabase) Migration(table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (err error) {
+func (scheme *schemeDatabase) MigrateTo(version int64) (err error) {
 	var (
-		transaction *Transaction
-		sqlTx       *sql.Tx
-
-		fieldRemoteNameArray []string
-		fieldLocalNameArray  []string
-		migrationName        string
+		transaction  *Transaction
+		historyArray []SchemeHistoryStable
+		revertArray  []SchemeHistoryStable
 	)
 
+	if scheme.historyTable == nil {
+		return ErrorSchemeMustHaveTable
+	}
+
 	transaction, err = scheme.transactionOpen()
 	if err != nil {
 		return
 	}
 
-	sqlTx = scheme.database.LockSqlTx()
-	scheme.database.Unlock()
+	allowColumnDrop := scheme.allowColumnDrop
+	scheme.allowColumnDrop = true
 
 	defer func() {
+		scheme.allowColumnDrop = allowColumnDrop
 		err = scheme.transactionClose(err)
+
+		if err != nil {
+			scheme.storageLocal = make(map[string]*SchemeStorageTable)
+			scheme.Import()
+		}
 	}()
 
-	for fieldRemoteName, fieldRemoteUnit := range fieldMapRemote.FieldMap {
-		var (
-			detected       bool
-			fieldLocalName string
-			fieldLocalUnit *SchemeStorageStable
-		)
+	historyArray, err = scheme.historyQuery(transaction)
+	if err != nil {
+		return
+	}
 
-		for fieldLocalName, fieldLocalUnit = range fieldMapLocal.FieldMap {
-			if !detected {
-				if fieldRemoteName == fieldLocalName {
-					detected = true
-				}
-			}
+	for _, historyUnit := range historyArray {
+		if historyUnit.HistoryVersion > version {
+			revertArray = append(revertArray, historyUnit)
+		}
+	}
 
-			if !detected {
-				if fieldRemoteUnit.LocalFieldName == fieldLocalUnit.LocalFieldName {
-					detected = true
-				}
+	tableArray := []*Table{}
+	tableOrder := map[string]int{}
+
+	for _, fieldMapRemote := range scheme.storageRemote {
+		if table, errTable := SchemeHelperFieldMapToTable(fieldMapRemote); errTable == nil {
+			tableArray = append(tableArray, table)
+		}
+	}
+
+	for tableIndex, table := range SchemeHelperTableOrder(tableArray) {
+		tableOrder[table.GetSqlName()] = tableIndex
+	}
+
+	sort.Slice(revertArray, func(i, j int) bool {
+		if revertArray[i].HistoryVersion != revertArray[j].HistoryVersion {
+			return revertArray[i].HistoryVersion > revertArray[j].HistoryVersion
+		}
+
+		if revertArray[i].HistoryKind != revertArray[j].HistoryKind {
+			return revertArray[i].HistoryKind == SchemeHistoryKindTable
+		}
+
+		if tableOrder[revertArray[i].HistoryName] != tableOrder[revertArray[j].HistoryName] {
+			return tableOrder[revertArray[i].HistoryName] > tableOrder[revertArray[j].HistoryName]
+		}
+
+		return revertArray[i].HistoryName > revertArray[j].HistoryName
+	})
+
+	for _, historyUnit := range revertArray {
+		if historyUnit.HistoryKind == SchemeHistoryKindStep {
+			_, err = scheme.revertStepDown(historyUnit)
+			if err != nil {
+				return
 			}
+		}
+	}
+
+	for _, historyUnit := range revertArray {
+		switch historyUnit.HistoryKind {
+		case SchemeHistoryKindStep:
+			err = scheme.revertStep(transaction, historyUnit)
+		case SchemeHistoryKindTable:
+			err = scheme.revertTable(transaction, historyUnit)
+		default:
+			err = ErrorSchemeHasUnsupportedHeader
+		}
+
+		if err != nil {
+			return
+		}
+
+		err = transaction.Execute(NewBuilderDelete(scheme.historyTable).WhereCondition(
+			NewConditionEqual("history_version", historyUnit.HistoryVersion),
+			NewConditionEqual("history_kind", historyUnit.HistoryKind),
+			NewConditionEqual("history_name", historyUnit.HistoryName),
+		))
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (scheme *schemeDatabase) Plan(tableArray ...*Table) (*SchemePlan, error) {
+	var (
+		sqlDialect string
+		plan       = &SchemePlan{TableArray: []*SchemePlanTable{}}
+	)
 
-			if detected {
-				break
+	if scheme.transport != nil {
+		sqlDialect = scheme.transport.GetDialect()
+	}
+
+	for _, table := range tableArray {
+		if table == nil {
+			return nil, ErrorTableIsNil
+		}
+
+		fieldMapLocal, err := SchemeHelperTableToFieldMap(SchemeHeaderStable, scheme.storageVersion, table)
+		if err != nil {
+			return nil, err
+		}
+
+		planTable := SchemeHelperPlanTable(sqlDialect, table, scheme.storageRemote[table.GetSqlName()], fieldMapLocal)
+
+		if planTable.Action != SchemePlanActionNone && len(planTable.Error) == 0 {
+			sqlArray, err := scheme.planExport(sqlDialect, table.GetSqlName(), scheme.storageRemote[table.GetSqlName()], fieldMapLocal)
+			if err != nil {
+				planTable.Error = err.Error()
 			}
+
+			planTable.SqlArray = append(planTable.SqlArray, sqlArray...)
+		}
+
+		plan.TableArray = append(plan.TableArray, planTable)
+	}
+
+	return plan, nil
+}
+
+func (scheme *schemeDatabase) planExport(sqlDialect string, tableName string, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (sqlArray []string, err error) {
+	var (
+		builderArray     []Builder
+		migrationVersion int64
+		updatedAt        = time.Now().Unix()
+	)
+
+	if scheme.historyTable != nil && scheme.transport != nil {
+		var responseInterface interface{}
+
+		responseInterface, err = scheme.transport.Query(NewBuilderSelect(scheme.historyTable))
+		if err != nil {
+			return
 		}
 
-		if detected {
-			fieldRemoteNameArray = append(fieldRemoteNameArray, fieldRemoteName)
-			fieldLocalNameArray = append(fieldLocalNameArray, fieldLocalName)
+		historyArray, ok := responseInterface.([]SchemeHistoryStable)
+		if !ok {
+			err = ErrorSchemeHasUnsupportedStruct
+			return
 		}
+
+		migrationVersion = schemeHelperHistoryStepVersion(historyArray)
 	}
 
-	if len(fieldRemoteNameArray) == 0 {
-		err = ErrorSchemeMigrationIsLimitedByVersion
-		return
+	schemeHelperExportPrepare(fieldMapLocal, migrationVersion, updatedAt)
+
+	switch storage := scheme.storage.(type) {
+	case interface {
+		helperExportBuilderArray(*SchemeStorageTable) []Builder
+	}:
+		builderArray = append(builderArray, storage.helperExportBuilderArray(fieldMapLocal)...)
 	}
 
-	migrationName = fmt.Sprintf("_migration_%s", table.GetSqlName())
-	err = transaction.Execute(NewBuilderCreate(table).CreateName(migrationName).IfNotExists(false))
-	if err != nil {
-		return
+	if scheme.historyTable != nil {
+		var historySnapshot *string
+
+		if fieldMapRemote != nil {
+			var snapshot string
+
+			snapshot, err = SchemeHelperFieldMapToSnapshot(fieldMapRemote)
+			if err != nil {
+				return
+			}
+
+			historySnapshot = &snapshot
+		}
+
+		builderArray = append(builderArray, NewBuilderInsert(scheme.historyTable).Value(SchemeHistoryStable{
+			HistoryVersion:   fieldMapLocal.SchemeVersion,
+			HistoryKind:      SchemeHistoryKindTable,
+			HistoryName:      tableName,
+			HistoryChecksum:  fieldMapLocal.GetHash(),
+			HistorySnapshot:  historySnapshot,
+			HistoryAppliedAt: updatedAt,
+		}))
 	}
 
-	_, err = sqlTx.Exec(fmt.Sprintf("INSERT INTO `%s` (%s) SELECT %s FROM `%s`", migrationName, strings.Join(fieldLocalNameArray, ", "), strings.Join(fieldRemoteNameArray, ", "), table.GetSqlName()))
-	if err != nil {
+	return SchemeHelperPlanSqlArray(sqlDialect, builderArray)
+}
+
+func (scheme *schemeDatabase) GetTableArray() (tableArray []*Table) {
+	for _, table := range scheme.tableMap {
+		if table != scheme.historyTable {
+			tableArray = append(tableArray, table)
+		}
+	}
+
+	sort.Slice(tableArray, func(i, j int) bool {
+		return tableArray[i].GetSqlName() < tableArray[j].GetSqlName()
+	})
+
+	return
+}
+
+func (scheme *schemeDatabase) GetStorage() SchemeStorage {
+	return scheme.storage
+}
+
+func (scheme *schemeDatabase) GetStorageTableArray() (storageTableArray []*SchemeStorageTable) {
+	for _, storageTable := range scheme.storageRemote {
+		storageTableArray = append(storageTableArray, storageTable)
+	}
+
+	sort.Slice(storageTableArray, func(i, j int) bool {
+		return storageTableArray[i].RemoteTableName < storageTableArray[j].RemoteTableName
+	})
+
+	return
+}
+
+func (scheme *schemeDatabase) History() (historyArray []SchemeHistoryStable, err error) {
+	var transaction *Transaction
+
+	if scheme.historyTable == nil {
 		return
 	}
 
-	_, err = sqlTx.Exec(fmt.Sprintf("DROP TABLE `%s`", table.GetSqlName()))
+	transaction, err = scheme.transactionOpen()
 	if err != nil {
 		return
 	}
 
-	_, err = sqlTx.Exec(fmt.Sprintf("ALTER TABLE `%s` RENAME TO `%s`", migrationName, table.GetSqlName()))
+	defer func() {
+		err = scheme.transactionClose(err)
+	}()
+
+	historyArray, err = scheme.historyQuery(transaction)
 	if err != nil {
 		return
 	}
 
+	sort.Slice(historyArray, func(i, j int) bool {
+		if historyArray[i].HistoryVersion != historyArray[j].HistoryVersion {
+			return historyArray[i].HistoryVersion < historyArray[j].HistoryVersion
+		}
+
+		if historyArray[i].HistoryKind != historyArray[j].HistoryKind {
+			return historyArray[i].HistoryKind < historyArray[j].HistoryKind
+		}
+
+		return historyArray[i].HistoryName < historyArray[j].HistoryName
+	})
+
 	return
 }
 
-func (scheme *schemeDatabase) Export() (err error) {
+func (scheme *schemeDatabase) Verify(tableArray ...*Table) (verify *SchemeVerify, err error) {
 	var (
 		transaction    *Transaction
-		requestArray   []interface{}
-		fieldMapRemote *SchemeStorageTable
-		ok             bool
+		sqlDialect     string
+		tableNameArray []string
+		liveNameArray  []string
+		tableMap       = make(map[string]*Table)
 	)
 
+	if scheme.transport == nil {
+		return nil, ErrorTransportIsNil
+	}
+
+	sqlDialect = scheme.transport.GetDialect()
+
+	for tableName := range scheme.storageRemote {
+		tableMap[tableName] = nil
+	}
+
+	for tableName, table := range scheme.tableMap {
+		tableMap[tableName] = table
+	}
+
+	for _, table := range tableArray {
+		if table == nil {
+			return nil, ErrorTableIsNil
+		}
+
+		tableMap[table.GetSqlName()] = table
+	}
+
+	for tableName := range tableMap {
+		tableNameArray = append(tableNameArray, tableName)
+	}
+
+	sort.Strings(tableNameArray)
+
 	transaction, err = scheme.transactionOpen()
 	if err != nil {
 		return
@@ -335,144 +784,290 @@ func (scheme *schemeDatabase) Export() (err error) {
 
 	defer func() {
 		err = scheme.transactionClose(err)
+		if err != nil {
+			verify = nil
+		}
 	}()
 
-	if len(scheme.storageRemote) == 0 {
-		err = transaction.Execute(NewBuilderCreate(scheme.storageStableTable).IfNotExists(true))
-		if err != nil {
-			return
+	verify = &SchemeVerify{TableArray: []*SchemeVerifyTable{}}
+
+	for _, tableName := range tableNameArray {
+		var (
+			fieldMapRemote = scheme.storageRemote[tableName]
+			fieldMapLocal  *SchemeStorageTable
+			fieldMapLive   *SchemeStorageTable
+			verifyTable    = &SchemeVerifyTable{Table: tableName}
+		)
+
+		if table := tableMap[tableName]; table != nil {
+			fieldMapLocal, err = SchemeHelperTableToFieldMap(SchemeHeaderStable, scheme.storageVersion, table)
+			if err != nil {
+				return
+			}
 		}
-	}
 
-	for tableName, fieldMapLocal := range scheme.storageLocal {
-		err = transaction.Execute(NewBuilderDelete(scheme.storageStableTable).Where(fmt.Sprintf("remote_table_name = '%s'", fieldMapLocal.RemoteTableName)))
+		fieldMapLive, err = SchemeHelperIntrospect(transaction, sqlDialect, tableName)
 		if err != nil {
 			return
 		}
 
-		fieldMapRemote, ok = scheme.storageRemote[fieldMapLocal.RemoteTableName]
-		if ok {
-			err = scheme.Migration(scheme.tableMap[tableName], fieldMapRemote, fieldMapLocal)
+		if fieldMapRemote != nil {
+			verifyTable.DriftArray = append(verifyTable.DriftArray, SchemeHelperVerifyLive(fieldMapRemote, fieldMapLive)...)
 		} else {
-			err = transaction.Execute(NewBuilderCreate(scheme.tableMap[tableName]).IfNotExists(true))
+			verifyTable.DriftArray = append(verifyTable.DriftArray, SchemeHelperVerifyLive(fieldMapLocal, fieldMapLive)...)
 		}
 
-		if err != nil {
-			return
+		verifyTable.DriftArray = append(verifyTable.DriftArray, SchemeHelperVerifyLocal(sqlDialect, tableMap[tableName], fieldMapRemote, fieldMapLocal)...)
+
+		verify.TableArray = append(verify.TableArray, verifyTable)
+	}
+
+	liveNameArray, err = SchemeHelperIntrospectTableNameArray(transaction, sqlDialect)
+	if err != nil {
+		return
+	}
+
+	for _, liveName := range liveNameArray {
+		if _, ok := tableMap[liveName]; ok || scheme.isStorageName(liveName) || strings.HasPrefix(liveName, "_migration_") {
+			continue
 		}
 
-		for _, fieldUnit := range fieldMapLocal.FieldMap {
-			fieldUnit.SchemeHeader = "V1"
-			fieldUnit.SchemeVersion = fieldMapLocal.SchemeVersion
-
-			requestArray = append(requestArray, SchemeStorageStable{
-				SchemeHeader:         fieldUnit.SchemeHeader,
-				SchemeVersion:        fieldUnit.SchemeVersion,
-				LocalTableName:       fieldUnit.LocalTableName,
-				RemoteTableName:      fieldUnit.RemoteTableName,
-				LocalFieldName:       fieldUnit.LocalFieldName,
-				RemoteFieldName:      fieldUnit.RemoteFieldName,
-				LocalFieldType:       fieldUnit.LocalFieldType,
-				RemoteFieldType:      fieldUnit.RemoteFieldType,
-				FieldIndex:           fieldUnit.FieldIndex,
-				FieldIsPrimaryKey:    fieldUnit.FieldIsPrimaryKey,
-				FieldIsAutoIncrement: fieldUnit.FieldIsAutoIncrement,
-				FieldIsNotNull:       fieldUnit.FieldIsNotNull,
-				FieldInUniqueGroup:   fieldUnit.FieldInUniqueGroup,
-				FieldValueDefault:    fieldUnit.FieldValueDefault,
-				FieldValueCheck:      fieldUnit.FieldValueCheck,
-			})
-		}
-	}
-
-	if len(requestArray) > 0 {
-		err = transaction.ExecuteReplaceValue(scheme.storageStableTable, requestArray...)
+		verify.TableArray = append(verify.TableArray, &SchemeVerifyTable{
+			Table:      liveName,
+			DriftArray: []SchemeVerifyDrift{{Source: SchemeVerifySourceLive, Kind: SchemeVerifyKindTableUnmanaged}},
+		})
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (scheme *schemeDatabase) exportHistory(tableName string, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (err error) {
+	var (
+		transaction     *Transaction
+		historySnapshot *string
+	)
+
+	if scheme.historyTable == nil {
+		return scheme.Export()
+	}
+
+	if fieldMapRemote != nil {
+		var snapshot string
+
+		snapshot, err = SchemeHelperFieldMapToSnapshot(fieldMapRemote)
 		if err != nil {
 			return
 		}
+
+		historySnapshot = &snapshot
 	}
 
-	if len(scheme.storageRemote) == 0 {
-		scheme.storageRemote = scheme.storageLocal
-	} else {
-		for _, tableUnit := range scheme.storageLocal {
-			scheme.storageRemote[tableUnit.RemoteTableName] = tableUnit
-		}
+	transaction, err = scheme.transactionOpen()
+	if err != nil {
+		return
 	}
 
-	scheme.storageLocal = make(map[string]*SchemeStorageTable)
+	defer func() {
+		err = scheme.transactionClose(err)
 
-	return
+		if err != nil {
+			if fieldMapRemote == nil {
+				delete(scheme.storageRemote, tableName)
+			} else {
+				scheme.storageRemote[tableName] = fieldMapRemote
+			}
+		}
+	}()
+
+	err = scheme.Export()
+	if err != nil {
+		return
+	}
+
+	return scheme.historyAppend(transaction, SchemeHistoryStable{
+		HistoryVersion:  fieldMapLocal.SchemeVersion,
+		HistoryKind:     SchemeHistoryKindTable,
+		HistoryName:     tableName,
+		HistoryChecksum: fieldMapLocal.GetHash(),
+		HistorySnapshot: historySnapshot,
+	})
 }
 
-//--------------------------------------------------------------------------------//
+func (scheme *schemeDatabase) migrationPopulated(transaction *Transaction, fieldMapRemote *SchemeStorageTable, fieldRemoteName string) (bool, error) {
+	probeTable, err := SchemeHelperFieldMapToTable(&SchemeStorageTable{
+		SchemeHeader:    fieldMapRemote.SchemeHeader,
+		SchemeVersion:   fieldMapRemote.SchemeVersion,
+		LocalTableName:  fieldMapRemote.LocalTableName,
+		RemoteTableName: fieldMapRemote.RemoteTableName,
+		FieldMap: map[string]*SchemeStorageStable{
+			fieldRemoteName: fieldMapRemote.FieldMap[fieldRemoteName],
+		},
+	})
+	if err != nil {
+		return false, err
+	}
 
-func (scheme *schemeDatabase) RegisterTable(tableName string, tableStruct interface{}) (*Table, error) {
+	responseInterface, err := transaction.Query(NewBuilderSelect(probeTable).WhereCondition(NewConditionIsNotNull(fieldRemoteName)).Limit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.ValueOf(responseInterface).Len() > 0, nil
+}
+
+func (scheme *schemeDatabase) historyQuery(transaction *Transaction) (historyArray []SchemeHistoryStable, err error) {
 	var (
-		table          *Table
-		fieldMapLocal  *SchemeStorageTable
-		fieldMapRemote *SchemeStorageTable
-		needExport     bool
-		err            error
+		responseInterface interface{}
+		ok                bool
 	)
 
-	table, err = NewTable(tableName, tableStruct)
+	responseInterface, err = transaction.Query(NewBuilderSelect(scheme.historyTable))
 	if err != nil {
-		return nil, err
+		return
 	}
 
-	fieldMapLocal, err = SchemeHelperTableToFieldMap("V1", scheme.storageVersion, table)
-	if err != nil {
-		return nil, err
+	historyArray, ok = responseInterface.([]SchemeHistoryStable)
+	if !ok {
+		err = ErrorSchemeHasUnsupportedStruct
 	}
 
-	fieldMapRemote = scheme.storageRemote[tableName]
+	return
+}
 
-	if fieldMapRemote == nil {
-		needExport = true
-	} else {
-		if fieldMapRemote.SchemeVersion <= fieldMapLocal.SchemeVersion {
-			if fieldMapRemote.SchemeHeader != fieldMapLocal.SchemeHeader || fieldMapRemote.SchemeVersion < fieldMapLocal.SchemeVersion || fieldMapRemote.GetHash() != fieldMapLocal.GetHash() {
-				needExport = true
-			}
-		} else {
-			err = ErrorSchemeMigrationIsLimitedByVersion
+func (scheme *schemeDatabase) isStorageName(tableName string) bool {
+	for _, storageName := range scheme.storage.GetTableNameArray() {
+		if storageName == tableName {
+			return true
 		}
 	}
 
+	if scheme.transactionLock != nil && scheme.transactionLock.lockTable.GetSqlName() == tableName {
+		return true
+	}
+
+	return false
+}
+
+func (scheme *schemeDatabase) historyStepVersion(transaction *Transaction) (stepVersion int64, err error) {
+	historyArray, err := scheme.historyQuery(transaction)
 	if err != nil {
-		return nil, err
+		return
 	}
 
-	if needExport {
-		scheme.storageLocal[tableName] = fieldMapLocal
-		scheme.tableMap[tableName] = table
+	return schemeHelperHistoryStepVersion(historyArray), nil
+}
 
-		err = scheme.Export()
-		if err != nil {
-			return nil, err
+func (scheme *schemeDatabase) historyAppend(transaction *Transaction, historyUnit SchemeHistoryStable) (err error) {
+	var (
+		responseInterface interface{}
+		responseArray     []SchemeHistoryStable
+		ok                bool
+	)
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(scheme.historyTable).WhereCondition(
+		NewConditionEqual("history_version", historyUnit.HistoryVersion),
+		NewConditionEqual("history_kind", historyUnit.HistoryKind),
+		NewConditionEqual("history_name", historyUnit.HistoryName),
+	))
+	if err != nil {
+		return
+	}
+
+	responseArray, ok = responseInterface.([]SchemeHistoryStable)
+	if !ok {
+		return ErrorSchemeHasUnsupportedStruct
+	}
+
+	if len(responseArray) > 0 {
+		return
+	}
+
+	historyUnit.HistoryAppliedAt = time.Now().Unix()
+	return transaction.ExecuteInsertValue(scheme.historyTable, historyUnit)
+}
+
+func (scheme *schemeDatabase) revertStepDown(historyUnit SchemeHistoryStable) (func(*Transaction) error, error) {
+	if scheme.migrationRegistry != nil {
+		migration := scheme.migrationRegistry.GetMigration(historyUnit.HistoryVersion)
+		if migration != nil && migration.Down != nil {
+			return migration.Down, nil
 		}
 	}
 
-	return table, nil
+	if historyUnit.HistorySnapshot != nil {
+		return SchemeHelperMigrationSqlFunc(*historyUnit.HistorySnapshot), nil
+	}
+
+	return nil, fmt.Errorf("%w: version %d (%s): go migration down is not persisted, register the migration with its down to revert it", ErrorSchemeMigrationIsIrreversible, historyUnit.HistoryVersion, historyUnit.HistoryName)
 }
 
-//--------------------------------------------------------------------------------//
+func (scheme *schemeDatabase) revertStep(transaction *Transaction, historyUnit SchemeHistoryStable) error {
+	down, err := scheme.revertStepDown(historyUnit)
+	if err != nil {
+		return err
+	}
 
-func NewSchemeDatabase(storageName string, storageVersion int64) Scheme {
+	err = down(transaction)
+	if err != nil {
+		return fmt.Errorf("scheme: migration version %d (%s): %w", historyUnit.HistoryVersion, historyUnit.HistoryName, err)
+	}
+
+	return nil
+}
+
+func (scheme *schemeDatabase) revertTable(transaction *Transaction, historyUnit SchemeHistoryStable) (err error) {
 	var (
-		storageV1Table     *Table
-		storageStableTable *Table
-		err                error
+		table          *Table
+		fieldMapRemote *SchemeStorageTable
+		fieldMapLocal  *SchemeStorageTable
 	)
 
-	storageV1Table, err = NewTable(storageName, SchemeStorageV1{})
+	if historyUnit.HistorySnapshot == nil {
+		fieldMapRemote = scheme.storageRemote[historyUnit.HistoryName]
+		if fieldMapRemote != nil {
+			table, err = SchemeHelperFieldMapToTable(fieldMapRemote)
+			if err != nil {
+				return
+			}
+
+			err = transaction.ExecuteDropTable(table)
+			if err != nil {
+				return
+			}
+		}
+
+		err = scheme.storage.Delete(transaction, historyUnit.HistoryName)
+		if err != nil {
+			return
+		}
+
+		delete(scheme.storageRemote, historyUnit.HistoryName)
+		delete(scheme.tableMap, historyUnit.HistoryName)
+		return
+	}
+
+	fieldMapLocal, err = SchemeHelperSnapshotToFieldMap(*historyUnit.HistorySnapshot)
 	if err != nil {
-		return nil
+		return
 	}
 
-	storageStableTable, err = NewTable(storageName, SchemeStorageStable{})
+	table, err = SchemeHelperFieldMapToTable(fieldMapLocal)
 	if err != nil {
+		return
+	}
+
+	scheme.storageLocal[historyUnit.HistoryName] = fieldMapLocal
+	scheme.tableMap[historyUnit.HistoryName] = table
+
+	return scheme.Export()
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewScheme(storage SchemeStorage, storageVersion int64) Scheme {
+	if storage == nil {
 		return nil
 	}
 
@@ -481,15 +1076,27 @@ func NewSchemeDatabase(storageName string, storageVersion int64) Scheme {
 		transport:        nil,
 		transactionCount: 0,
 		transaction:      nil,
+		transactionLock:  nil,
 
 		mutex: make(chan interface{}, 1),
 
-		storageName:        storageName,
-		storageVersion:     storageVersion,
-		storageV1Table:     storageV1Table,
-		storageStableTable: storageStableTable,
-		storageRemote:      map[string]*SchemeStorageTable{},
-		storageLocal:       map[string]*SchemeStorageTable{},
-		tableMap:           map[string]*Table{},
+		storage:         storage,
+		storageVersion:  storageVersion,
+		storageRemote:   map[string]*SchemeStorageTable{},
+		storageLocal:    map[string]*SchemeStorageTable{},
+		storageImported: false,
+		tableMap:        map[string]*Table{},
+
+		migrationRegistry: nil,
+		historyTable:      nil,
 	}
 }
+
+func NewSchemeDatabase(storageName string, storageVersion int64) Scheme {
+	storage := NewSchemeStorageDatabase(storageName)
+	if storage == nil {
+		return nil
+	}
+
+	return NewScheme(storage, storageVersion)
+}
@@ -0,0 +1,106 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperTableOrder(tableArray []*Table) []*Table {
+	var (
+		tableSorted  = append([]*Table{}, tableArray...)
+		tableOrder   = make([]*Table, 0, len(tableArray))
+		tableVisited = map[*Table]bool{}
+		tableByName  = map[string]*Table{}
+		tableVisit   func(table *Table, tablePath map[*Table]bool)
+	)
+
+	sort.SliceStable(tableSorted, func(i, j int) bool {
+		return tableSorted[i].GetSqlName() < tableSorted[j].GetSqlName()
+	})
+
+	for _, table := range tableSorted {
+		tableByName[table.GetSqlName()] = table
+	}
+
+	tableVisit = func(table *Table, tablePath map[*Table]bool) {
+		if tableVisited[table] || tablePath[table] {
+			return
+		}
+
+		tablePath[table] = true
+
+		for _, tableField := range table.GetReferenceArray() {
+			if tableParent := tableByName[*tableField.ReferenceTable()]; tableParent != nil && tableParent != table {
+				tableVisit(tableParent, tablePath)
+			}
+		}
+
+		delete(tablePath, table)
+
+		tableVisited[table] = true
+		tableOrder = append(tableOrder, table)
+	}
+
+	for _, table := range tableSorted {
+		tableVisit(table, map[*Table]bool{})
+	}
+
+	return tableOrder
+}
+
+func SchemeHelperDumpSchemaArray(sqlDialect string, tableArray []*Table) (sqlArray []string, err error) {
+	switch sqlDialect {
+	case "sqlite", "mysql":
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrorBuilderHasUnsupportedDialect, sqlDialect)
+	}
+
+	for _, table := range tableArray {
+		if table == nil {
+			return nil, ErrorTableIsNil
+		}
+	}
+
+	for _, table := range SchemeHelperTableOrder(tableArray) {
+		var builderString string
+
+		builderString, _, err = NewBuilderCreate(table).SqlDialect(sqlDialect).IfNotExists(false).Build()
+		if err != nil {
+			return
+		}
+
+		sqlArray = append(sqlArray, builderString)
+
+		for _, indexName := range table.GetIndexNameArray() {
+			builderString, _, err = NewBuilderCreateIndex(table, indexName).SqlDialect(sqlDialect).IfNotExists(false).Build()
+			if err != nil {
+				return
+			}
+
+			sqlArray = append(sqlArray, builderString)
+		}
+	}
+
+	return
+}
+
+func SchemeHelperDumpSchema(writer io.Writer, sqlDialect string, tableArray []*Table) error {
+	sqlArray, err := SchemeHelperDumpSchemaArray(sqlDialect, tableArray)
+	if err != nil {
+		return err
+	}
+
+	for _, sqlUnit := range sqlArray {
+		_, err = fmt.Fprintf(writer, "%s;\n\n", sqlUnit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,96 @@
+package sqlctrl
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeDumpOrder(t *testing.T) {
+	tableArray := []*Table{}
+
+	for tableName, tableStruct := range map[string]interface{}{
+		"customers": testRelationCustomer{},
+		"items":     testRelationItem{},
+		"orders":    testRelationOrder{},
+	} {
+		table, err := NewTable(tableName, tableStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tableArray = append(tableArray, table)
+	}
+
+	tableNameArray := []string{}
+	for _, table := range SchemeHelperTableOrder(tableArray) {
+		tableNameArray = append(tableNameArray, table.GetSqlName())
+	}
+
+	if tableOrder := strings.Join(tableNameArray, ", "); tableOrder != "customers, orders, items" {
+		t.Errorf("tables are ordered as %s, want referenced tables first", tableOrder)
+	}
+}
+
+func TestSchemeDump(t *testing.T) {
+	var dump bytes.Buffer
+
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "dump.db"), 1, nil)
+
+	for tableName, tableStruct := range map[string]interface{}{
+		"users":     testIndexUser{},
+		"customers": testRelationCustomer{},
+		"orders":    testRelationOrder{},
+	} {
+		_, err := database.RegisterTable(tableName, tableStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := database.DumpSchema(&dump, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(dump.String(), "CREATE INDEX users_mail_ix") {
+		t.Errorf("dump misses the users indexes:\n%s", dump.String())
+	}
+
+	sqlDb, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "replay.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer sqlDb.Close()
+
+	for _, sqlUnit := range strings.Split(dump.String(), ";\n\n") {
+		if len(strings.TrimSpace(sqlUnit)) == 0 {
+			continue
+		}
+
+		_, err = sqlDb.Exec(sqlUnit)
+		if err != nil {
+			t.Errorf("dump statement %q does not replay: %v", sqlUnit, err)
+		}
+	}
+
+	err = database.DumpSchema(&bytes.Buffer{}, "mysql")
+	if !errors.Is(err, ErrorBuilderHasUnsupportedDialect) {
+		t.Errorf("mysql dump of a partial index returned %v, want an unsupported dialect error", err)
+	}
+
+	err = database.DumpSchema(&bytes.Buffer{}, "oracle")
+	if !errors.Is(err, ErrorBuilderHasUnsupportedDialect) {
+		t.Errorf("dump for an unknown dialect returned %v, want an unsupported dialect error", err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,347 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+var (
+	schemeIntrospectUniqueRegexp = regexp.MustCompile("(?i)CONSTRAINT\\s+[`\"]?([^\\s`\"]+)[`\"]?\\s+UNIQUE\\s*\\(([^)]*)\\)")
+	schemeIntrospectCheckRegexp  = regexp.MustCompile("(?i)CONSTRAINT\\s+[`\"]?([^\\s`\"]+)[`\"]?\\s+CHECK\\s*\\(")
+	schemeIntrospectNumberRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+)
+
+type schemeIntrospectTableName struct {
+	TableName string `sql:"NAME=table_name"`
+}
+
+type schemeIntrospectTableSql struct {
+	TableSql string `sql:"NAME=table_sql"`
+}
+
+type schemeIntrospectIndexColumn struct {
+	IndexName  string `sql:"NAME=index_name"`
+	ColumnName string `sql:"NAME=column_name"`
+}
+
+type schemeIntrospectCheck struct {
+	CheckName   string `sql:"NAME=check_name"`
+	CheckClause string `sql:"NAME=check_clause"`
+}
+
+type schemeIntrospectSqliteColumn struct {
+	ColumnIndex   int64   `sql:"NAME=cid"`
+	ColumnName    string  `sql:"NAME=name"`
+	ColumnType    string  `sql:"NAME=type"`
+	ColumnNotNull int64   `sql:"NAME=notnull"`
+	ColumnDefault *string `sql:"NAME=dflt_value"`
+	ColumnPrimary int64   `sql:"NAME=pk"`
+}
+
+type schemeIntrospectMysqlColumn struct {
+	ColumnName     string  `sql:"NAME=column_name"`
+	ColumnType     string  `sql:"NAME=column_type"`
+	ColumnNullable string  `sql:"NAME=is_nullable"`
+	ColumnDefault  *string `sql:"NAME=column_default"`
+	ColumnExtra    string  `sql:"NAME=extra"`
+	ColumnIndex    int64   `sql:"NAME=ordinal_position"`
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperIntrospectQuery(transaction *Transaction, responseStruct interface{}, rawString string, rawOption ...interface{}) (interface{}, error) {
+	responseTable, err := NewTable("", responseStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.Query(NewBuilderRaw(rawString, rawOption...).Response(responseTable))
+}
+
+func schemeHelperIntrospectConstraintName(tableName string, constraintName string, constraintSuffix string) string {
+	constraintName = strings.TrimSuffix(constraintName, constraintSuffix)
+
+	for _, constraintPrefix := range []string{fmt.Sprintf("_migration_%s_", tableName), fmt.Sprintf("%s_", tableName)} {
+		if strings.HasPrefix(constraintName, constraintPrefix) {
+			return strings.TrimPrefix(constraintName, constraintPrefix)
+		}
+	}
+
+	return constraintName
+}
+
+func schemeHelperIntrospectCheckArray(tableSql string) (checkArray []schemeIntrospectCheck) {
+	for _, checkMatch := range schemeIntrospectCheckRegexp.FindAllStringSubmatchIndex(tableSql, -1) {
+		var (
+			checkDepth = 1
+			checkIndex = checkMatch[1]
+		)
+
+		for checkIndex < len(tableSql) && checkDepth > 0 {
+			switch tableSql[checkIndex] {
+			case '(':
+				checkDepth++
+			case ')':
+				checkDepth--
+			}
+
+			checkIndex++
+		}
+
+		if checkDepth == 0 {
+			checkArray = append(checkArray, schemeIntrospectCheck{
+				CheckName:   tableSql[checkMatch[2]:checkMatch[3]],
+				CheckClause: tableSql[checkMatch[1] : checkIndex-1],
+			})
+		}
+	}
+
+	return
+}
+
+func schemeHelperIntrospectUnique(storageTable *SchemeStorageTable, indexColumnArray []schemeIntrospectIndexColumn, indexNameMap map[string]string) {
+	indexColumnMap := map[string][]string{}
+	indexNameArray := []string{}
+
+	for _, indexColumn := range indexColumnArray {
+		if _, ok := indexColumnMap[indexColumn.IndexName]; !ok {
+			indexNameArray = append(indexNameArray, indexColumn.IndexName)
+		}
+
+		indexColumnMap[indexColumn.IndexName] = append(indexColumnMap[indexColumn.IndexName], indexColumn.ColumnName)
+	}
+
+	for _, indexName := range indexNameArray {
+		uniqueName := indexName
+		if groupName, ok := indexNameMap[strings.Join(indexColumnMap[indexName], ",")]; ok {
+			uniqueName = groupName
+		}
+
+		for _, columnName := range indexColumnMap[indexName] {
+			if storageField := storageTable.FieldMap[columnName]; storageField != nil {
+				groupName := uniqueName
+				storageField.FieldInUniqueGroup = &groupName
+			}
+		}
+	}
+}
+
+func schemeHelperIntrospectCheck(storageTable *SchemeStorageTable, checkArray []schemeIntrospectCheck) {
+	for _, checkUnit := range checkArray {
+		fieldName := schemeHelperIntrospectConstraintName(storageTable.RemoteTableName, checkUnit.CheckName, "_ck")
+
+		if storageField := storageTable.FieldMap[fieldName]; storageField != nil {
+			checkClause := checkUnit.CheckClause
+			storageField.FieldValueCheck = &checkClause
+		}
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperIntrospectSqlite(transaction *Transaction, tableName string) (storageTable *SchemeStorageTable, err error) {
+	var (
+		responseInterface interface{}
+		columnArray       []schemeIntrospectSqliteColumn
+		indexColumnArray  []schemeIntrospectIndexColumn
+		tableSqlArray     []schemeIntrospectTableSql
+		indexNameMap      = map[string]string{}
+		primaryArray      []*SchemeStorageStable
+	)
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectTableSql{}, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", tableName)
+	if err != nil {
+		return
+	}
+
+	tableSqlArray = responseInterface.([]schemeIntrospectTableSql)
+	if len(tableSqlArray) == 0 {
+		return nil, nil
+	}
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectSqliteColumn{}, "SELECT cid, name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid", tableName)
+	if err != nil {
+		return
+	}
+
+	columnArray = responseInterface.([]schemeIntrospectSqliteColumn)
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectIndexColumn{}, "SELECT il.name, ii.name FROM pragma_index_list(?) AS il, pragma_index_info(il.name) AS ii WHERE il.\"unique\" = 1 AND il.origin <> 'pk' ORDER BY il.seq, ii.seqno", tableName)
+	if err != nil {
+		return
+	}
+
+	indexColumnArray = responseInterface.([]schemeIntrospectIndexColumn)
+
+	storageTable = &SchemeStorageTable{
+		LocalTableName:  "",
+		RemoteTableName: tableName,
+		FieldMap:        map[string]*SchemeStorageStable{},
+	}
+
+	for _, columnUnit := range columnArray {
+		storageField := &SchemeStorageStable{
+			RemoteTableName: tableName,
+			RemoteFieldName: columnUnit.ColumnName,
+			RemoteFieldType: columnUnit.ColumnType,
+
+			FieldIndex:        int(columnUnit.ColumnIndex),
+			FieldIsPrimaryKey: columnUnit.ColumnPrimary > 0,
+			FieldIsNotNull:    columnUnit.ColumnNotNull != 0,
+			FieldValueDefault: columnUnit.ColumnDefault,
+		}
+
+		if storageField.FieldIsPrimaryKey {
+			primaryArray = append(primaryArray, storageField)
+		}
+
+		storageTable.FieldMap[columnUnit.ColumnName] = storageField
+	}
+
+	if len(primaryArray) == 1 && strings.Contains(strings.ToUpper(tableSqlArray[0].TableSql), "AUTOINCREMENT") {
+		primaryArray[0].FieldIsAutoIncrement = true
+	}
+
+	for _, uniqueMatch := range schemeIntrospectUniqueRegexp.FindAllStringSubmatch(tableSqlArray[0].TableSql, -1) {
+		columnNameArray := []string{}
+		for _, columnName := range strings.Split(uniqueMatch[2], ",") {
+			columnNameArray = append(columnNameArray, strings.Trim(columnName, " `\"[]"))
+		}
+
+		indexNameMap[strings.Join(columnNameArray, ",")] = schemeHelperIntrospectConstraintName(tableName, uniqueMatch[1], "_uq")
+	}
+
+	schemeHelperIntrospectUnique(storageTable, indexColumnArray, indexNameMap)
+	schemeHelperIntrospectCheck(storageTable, schemeHelperIntrospectCheckArray(tableSqlArray[0].TableSql))
+
+	return
+}
+
+func schemeHelperIntrospectMysql(transaction *Transaction, tableName string) (storageTable *SchemeStorageTable, err error) {
+	var (
+		responseInterface interface{}
+		columnArray       []schemeIntrospectMysqlColumn
+		indexColumnArray  []schemeIntrospectIndexColumn
+		uniqueColumnArray []schemeIntrospectIndexColumn
+		checkArray        []schemeIntrospectCheck
+	)
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectMysqlColumn{}, "SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA, ORDINAL_POSITION FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", tableName)
+	if err != nil {
+		return
+	}
+
+	columnArray = responseInterface.([]schemeIntrospectMysqlColumn)
+	if len(columnArray) == 0 {
+		return nil, nil
+	}
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectIndexColumn{}, "SELECT INDEX_NAME, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND NON_UNIQUE = 0 ORDER BY INDEX_NAME, SEQ_IN_INDEX", tableName)
+	if err != nil {
+		return
+	}
+
+	indexColumnArray = responseInterface.([]schemeIntrospectIndexColumn)
+
+	responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectCheck{}, "SELECT tc.CONSTRAINT_NAME, cc.CHECK_CLAUSE FROM information_schema.TABLE_CONSTRAINTS AS tc JOIN information_schema.CHECK_CONSTRAINTS AS cc ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'CHECK'", tableName)
+	if err != nil {
+		return
+	}
+
+	checkArray = responseInterface.([]schemeIntrospectCheck)
+
+	storageTable = &SchemeStorageTable{
+		LocalTableName:  "",
+		RemoteTableName: tableName,
+		FieldMap:        map[string]*SchemeStorageStable{},
+	}
+
+	for _, columnUnit := range columnArray {
+		if columnUnit.ColumnDefault != nil {
+			columnDefault := schemeHelperIntrospectMysqlDefault(columnUnit)
+			columnUnit.ColumnDefault = &columnDefault
+		}
+
+		storageTable.FieldMap[columnUnit.ColumnName] = &SchemeStorageStable{
+			RemoteTableName: tableName,
+			RemoteFieldName: columnUnit.ColumnName,
+			RemoteFieldType: columnUnit.ColumnType,
+
+			FieldIndex:           int(columnUnit.ColumnIndex) - 1,
+			FieldIsAutoIncrement: strings.Contains(strings.ToLower(columnUnit.ColumnExtra), "auto_increment"),
+			FieldIsNotNull:       columnUnit.ColumnNullable == "NO",
+			FieldValueDefault:    columnUnit.ColumnDefault,
+		}
+	}
+
+	for _, indexColumn := range indexColumnArray {
+		if indexColumn.IndexName == "PRIMARY" {
+			if storageField := storageTable.FieldMap[indexColumn.ColumnName]; storageField != nil {
+				storageField.FieldIsPrimaryKey = true
+			}
+		} else {
+			indexColumn.IndexName = schemeHelperIntrospectConstraintName(tableName, indexColumn.IndexName, "_uq")
+			uniqueColumnArray = append(uniqueColumnArray, indexColumn)
+		}
+	}
+
+	schemeHelperIntrospectUnique(storageTable, uniqueColumnArray, nil)
+	schemeHelperIntrospectCheck(storageTable, checkArray)
+
+	return
+}
+
+func schemeHelperIntrospectMysqlDefault(columnUnit schemeIntrospectMysqlColumn) string {
+	columnDefault := *columnUnit.ColumnDefault
+
+	if strings.Contains(strings.ToUpper(columnUnit.ColumnExtra), "DEFAULT_GENERATED") {
+		return fmt.Sprintf("(%s)", columnDefault)
+	}
+
+	if schemeIntrospectNumberRegexp.MatchString(columnDefault) {
+		return columnDefault
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(columnDefault, "'", "''"))
+}
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperIntrospect(transaction *Transaction, sqlDialect string, tableName string) (*SchemeStorageTable, error) {
+	switch sqlDialect {
+	case "sqlite":
+		return schemeHelperIntrospectSqlite(transaction, tableName)
+	case "mysql":
+		return schemeHelperIntrospectMysql(transaction, tableName)
+	default:
+		return nil, ErrorSchemeIntrospectHasUnsupportedDialect
+	}
+}
+
+func SchemeHelperIntrospectTableNameArray(transaction *Transaction, sqlDialect string) (tableNameArray []string, err error) {
+	var responseInterface interface{}
+
+	switch sqlDialect {
+	case "sqlite":
+		responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectTableName{}, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	case "mysql":
+		responseInterface, err = schemeHelperIntrospectQuery(transaction, schemeIntrospectTableName{}, "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME")
+	default:
+		err = ErrorSchemeIntrospectHasUnsupportedDialect
+	}
+
+	if err != nil {
+		return
+	}
+
+	for _, tableNameUnit := range responseInterface.([]schemeIntrospectTableName) {
+		tableNameArray = append(tableNameArray, tableNameUnit.TableName)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,355 @@
+package sqlctrl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	schemeLockSqliteBusy   = 5
+	schemeLockSqliteLocked = 6
+)
+
+var (
+	SchemeLockLease   = 1 * time.Minute
+	SchemeLockTimeout = 5 * time.Minute
+	SchemeLockRetry   = 100 * time.Millisecond
+)
+
+type SchemeLockV1 struct {
+	LockName     string `sql:"NAME=lock_name | TYPE=VARCHAR(256) | PRIMARY_KEY"`
+	LockOwner    string `sql:"NAME=lock_owner | NOT_NULL"`
+	LockExpireAt int64  `sql:"NAME=lock_expire_at | NOT_NULL"`
+}
+
+type SchemeLockStable SchemeLockV1
+
+type schemeLock struct {
+	lockName  string
+	lockOwner string
+	lockTable *Table
+
+	sqlDialect string
+	sqlConn    *sql.Conn
+}
+
+//--------------------------------------------------------------------------------//
+
+func (lock *schemeLock) helperExecute(ctx context.Context, sqlConn *sql.Conn, builderRequest Builder) error {
+	builderString, builderOption, err := builderRequest.Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlConn.ExecContext(ctx, builderString, builderOption...)
+	return err
+}
+
+func (lock *schemeLock) helperQuery(ctx context.Context, sqlConn *sql.Conn) (lockArray []SchemeLockStable, err error) {
+	builderString, builderOption, err := NewBuilderSelect(lock.lockTable).WhereCondition(NewConditionEqual("lock_name", lock.lockName)).Build()
+	if err != nil {
+		return
+	}
+
+	sqlRowArray, err := sqlConn.QueryContext(ctx, builderString, builderOption...)
+	if err != nil {
+		return
+	}
+
+	defer sqlRowArray.Close()
+
+	for sqlRowArray.Next() {
+		var lockUnit SchemeLockStable
+
+		err = sqlRowArray.Scan(&lockUnit.LockName, &lockUnit.LockOwner, &lockUnit.LockExpireAt)
+		if err != nil {
+			return
+		}
+
+		lockArray = append(lockArray, lockUnit)
+	}
+
+	err = sqlRowArray.Err()
+	return
+}
+
+func (lock *schemeLock) helperLease() Builder {
+	return NewBuilderReplace(lock.lockTable).Value(SchemeLockStable{
+		LockName:     lock.lockName,
+		LockOwner:    lock.lockOwner,
+		LockExpireAt: time.Now().Add(SchemeLockLease).Unix(),
+	})
+}
+
+func (lock *schemeLock) isBusy(err error) bool {
+	var errCode interface {
+		Code() int
+	}
+
+	if !errors.As(err, &errCode) {
+		return false
+	}
+
+	switch errCode.Code() & 0xff {
+	case schemeLockSqliteBusy, schemeLockSqliteLocked:
+		return true
+	}
+
+	return false
+}
+
+//--------------------------------------------------------------------------------//
+
+func (lock *schemeLock) trySqlite(ctx context.Context, sqlConn *sql.Conn) (acquired bool, expireAt time.Time, err error) {
+	var lockArray []SchemeLockStable
+
+	_, err = sqlConn.ExecContext(ctx, "BEGIN EXCLUSIVE")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if acquired {
+			_, err = sqlConn.ExecContext(ctx, "COMMIT")
+			acquired = err == nil
+		} else {
+			sqlConn.ExecContext(ctx, "ROLLBACK")
+		}
+	}()
+
+	err = lock.helperExecute(ctx, sqlConn, NewBuilderCreate(lock.lockTable).SqlDialect(lock.sqlDialect).IfNotExists(true))
+	if err != nil {
+		return
+	}
+
+	lockArray, err = lock.helperQuery(ctx, sqlConn)
+	if err != nil {
+		return
+	}
+
+	for _, lockUnit := range lockArray {
+		if lockUnit.LockOwner != lock.lockOwner && lockUnit.LockExpireAt > time.Now().Unix() {
+			expireAt = time.Unix(lockUnit.LockExpireAt, 0)
+			return
+		}
+	}
+
+	err = lock.helperExecute(ctx, sqlConn, lock.helperLease())
+	acquired = err == nil
+
+	return
+}
+
+func (lock *schemeLock) lockSqlite(ctx context.Context, sqlDb *sql.DB) (err error) {
+	var (
+		sqlConn  *sql.Conn
+		acquired bool
+		expireAt time.Time
+		deadline = time.Now().Add(SchemeLockTimeout)
+	)
+
+	sqlConn, err = sqlDb.Conn(ctx)
+	if err != nil {
+		return
+	}
+
+	defer sqlConn.Close()
+
+	for {
+		acquired, expireAt, err = lock.trySqlite(ctx, sqlConn)
+		if acquired {
+			return nil
+		}
+
+		if err != nil && !lock.isBusy(err) {
+			return
+		}
+
+		if expireAt.After(deadline) {
+			deadline = expireAt.Add(SchemeLockRetry)
+		}
+
+		if time.Now().After(deadline) {
+			if err == nil {
+				err = ErrorSchemeLockIsTimedOut
+			}
+
+			return fmt.Errorf("%w: %s", ErrorSchemeLockIsTimedOut, err.Error())
+		}
+
+		time.Sleep(SchemeLockRetry)
+	}
+}
+
+func (lock *schemeLock) lockMysql(ctx context.Context, sqlDb *sql.DB) (err error) {
+	var (
+		sqlConn  *sql.Conn
+		acquired sql.NullInt64
+	)
+
+	sqlConn, err = sqlDb.Conn(ctx)
+	if err != nil {
+		return
+	}
+
+	err = lock.helperExecute(ctx, sqlConn, NewBuilderCreate(lock.lockTable).SqlDialect(lock.sqlDialect).IfNotExists(true))
+	if err != nil {
+		sqlConn.Close()
+		return
+	}
+
+	err = sqlConn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lock.lockName, int64(SchemeLockTimeout/time.Second)).Scan(&acquired)
+	if err == nil && (!acquired.Valid || acquired.Int64 != 1) {
+		err = ErrorSchemeLockIsTimedOut
+	}
+
+	if err == nil {
+		err = lock.helperExecute(ctx, sqlConn, lock.helperLease())
+		if err != nil {
+			sqlConn.ExecContext(ctx, "DO RELEASE_LOCK(?)", lock.lockName)
+		}
+	}
+
+	if err != nil {
+		sqlConn.Close()
+		return
+	}
+
+	lock.sqlConn = sqlConn
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (lock *schemeLock) Lock(transport Transport) (err error) {
+	ctx := context.Background()
+
+	sqlDb := transport.LockSqlDb()
+	transport.Unlock()
+
+	if sqlDb == nil {
+		return
+	}
+
+	switch lock.sqlDialect {
+	case "sqlite":
+		return lock.lockSqlite(ctx, sqlDb)
+	case "mysql":
+		return lock.lockMysql(ctx, sqlDb)
+	}
+
+	return
+}
+
+func (lock *schemeLock) Unlock(transport Transport) (err error) {
+	ctx := context.Background()
+
+	switch lock.sqlDialect {
+	case "sqlite":
+		sqlDb := transport.LockSqlDb()
+		transport.Unlock()
+
+		if sqlDb == nil {
+			return
+		}
+
+		var sqlConn *sql.Conn
+
+		sqlConn, err = sqlDb.Conn(ctx)
+		if err != nil {
+			return
+		}
+
+		defer sqlConn.Close()
+
+		deadline := time.Now().Add(SchemeLockTimeout)
+
+		for {
+			err = lock.helperExecute(ctx, sqlConn, NewBuilderDelete(lock.lockTable).WhereCondition(
+				NewConditionEqual("lock_name", lock.lockName),
+				NewConditionEqual("lock_owner", lock.lockOwner),
+			))
+
+			if err == nil || !lock.isBusy(err) || time.Now().After(deadline) {
+				return
+			}
+
+			time.Sleep(SchemeLockRetry)
+		}
+	case "mysql":
+		if lock.sqlConn == nil {
+			return
+		}
+
+		defer func() {
+			lock.sqlConn.Close()
+			lock.sqlConn = nil
+		}()
+
+		err = lock.helperExecute(ctx, lock.sqlConn, NewBuilderDelete(lock.lockTable).WhereCondition(
+			NewConditionEqual("lock_name", lock.lockName),
+			NewConditionEqual("lock_owner", lock.lockOwner),
+		))
+
+		_, errRelease := lock.sqlConn.ExecContext(ctx, "DO RELEASE_LOCK(?)", lock.lockName)
+		if err == nil {
+			err = errRelease
+		}
+	}
+
+	return
+}
+
+func (lock *schemeLock) Renew(transaction *Transaction) (err error) {
+	var (
+		builderString string
+		builderOption []interface{}
+	)
+
+	switch lock.sqlDialect {
+	case "sqlite":
+		sqlTx := transaction.transport.LockSqlTx()
+		defer transaction.transport.Unlock()
+
+		if sqlTx == nil {
+			return
+		}
+
+		builderString, builderOption, err = lock.helperLease().Build()
+		if err != nil {
+			return
+		}
+
+		_, err = sqlTx.Exec(builderString, builderOption...)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func newSchemeLock(storageName string, sqlDialect string) (*schemeLock, error) {
+	lockTable, err := NewTable(fmt.Sprintf("%s_lock", storageName), SchemeLockStable{})
+	if err != nil {
+		return nil, err
+	}
+
+	hostName, _ := os.Hostname()
+
+	return &schemeLock{
+		lockName:  storageName,
+		lockOwner: fmt.Sprintf("%s:%d:%d", hostName, os.Getpid(), time.Now().UnixNano()),
+		lockTable: lockTable,
+
+		sqlDialect: sqlDialect,
+		sqlConn:    nil,
+	}, nil
+}
+
+//--------------------------------------------------------------------------------//
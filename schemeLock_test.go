@@ -0,0 +1,67 @@
+package sqlctrl
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+func testLockTimeout(t *testing.T, timeout time.Duration) {
+	t.Helper()
+
+	lockTimeout, lockRetry := SchemeLockTimeout, SchemeLockRetry
+	SchemeLockTimeout, SchemeLockRetry = timeout, 10*time.Millisecond
+
+	t.Cleanup(func() {
+		SchemeLockTimeout, SchemeLockRetry = lockTimeout, lockRetry
+	})
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeLockHeld(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "held.db")
+
+	testLockTimeout(t, 100*time.Millisecond)
+	testStorageSeed(t, databasePath, "scheme_lock", SchemeLockStable{}, SchemeLockStable{
+		LockName:     "scheme",
+		LockOwner:    "other",
+		LockExpireAt: time.Now().Add(2 * time.Second).Unix(),
+	})
+
+	timeStart := time.Now()
+
+	testSchemeOpen(t, databasePath, 1, nil)
+
+	if timeWait := time.Since(timeStart); timeWait < time.Second {
+		t.Errorf("database opened after %v while another owner held the lease", timeWait)
+	}
+}
+
+func TestSchemeLockExpired(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "expired.db")
+
+	testLockTimeout(t, time.Second)
+	testStorageSeed(t, databasePath, "scheme_lock", SchemeLockStable{}, SchemeLockStable{
+		LockName:     "scheme",
+		LockOwner:    "other",
+		LockExpireAt: time.Now().Add(-time.Minute).Unix(),
+	})
+
+	database, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	_, err := database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lockArray := testStorageQuery(t, database, "scheme_lock", SchemeLockStable{}).([]SchemeLockStable); len(lockArray) != 0 {
+		t.Errorf("lock table holds %v after the scheme transaction, want the lease released", lockArray)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,308 @@
+package sqlctrl
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+var schemeMigrationFileRegexp = regexp.MustCompile(`^([0-9]+)_([^.]+?)(\.up|\.down)?\.sql$`)
+
+//--------------------------------------------------------------------------------//
+
+type SchemeMigration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	Up       func(*Transaction) error
+	Down     func(*Transaction) error
+	DownSql  *string
+}
+
+type SchemeMigrationRegistry struct {
+	mutex        chan interface{}
+	migrationMap map[int64]*SchemeMigration
+}
+
+//--------------------------------------------------------------------------------//
+
+func (registry *SchemeMigrationRegistry) helperRegister(migration *SchemeMigration) error {
+	if migration.Version <= 0 || len(migration.Name) == 0 || migration.Up == nil {
+		return ErrorGenericInvalidArgument
+	}
+
+	registry.mutex <- true
+	defer func() {
+		<-registry.mutex
+	}()
+
+	if registry.migrationMap[migration.Version] != nil {
+		return fmt.Errorf("%w: version %d", ErrorSchemeMigrationIsDuplicated, migration.Version)
+	}
+
+	registry.migrationMap[migration.Version] = migration
+	return nil
+}
+
+func (registry *SchemeMigrationRegistry) Register(migration *SchemeMigration) error {
+	if migration == nil {
+		return ErrorGenericInvalidArgument
+	}
+
+	if len(migration.Checksum) == 0 {
+		migration.Checksum = schemeHelperMigrationFuncChecksum(migration.Version, migration.Name)
+	}
+
+	return registry.helperRegister(migration)
+}
+
+// RegisterFunc checksums only the version and name, so edits to the body of up
+// go undetected; use Register with an explicit Checksum to track them.
+func (registry *SchemeMigrationRegistry) RegisterFunc(version int64, name string, up func(*Transaction) error, down func(*Transaction) error) error {
+	return registry.helperRegister(&SchemeMigration{
+		Version:  version,
+		Name:     name,
+		Checksum: schemeHelperMigrationFuncChecksum(version, name),
+		Up:       up,
+		Down:     down,
+		DownSql:  nil,
+	})
+}
+
+func (registry *SchemeMigrationRegistry) RegisterSql(version int64, name string, upSqlScript string, downSqlScript string) error {
+	if len(strings.TrimSpace(upSqlScript)) == 0 {
+		return ErrorGenericInvalidArgument
+	}
+
+	migration := &SchemeMigration{
+		Version:  version,
+		Name:     name,
+		Checksum: SchemeHelperMigrationChecksum(upSqlScript),
+		Up:       SchemeHelperMigrationSqlFunc(upSqlScript),
+		Down:     nil,
+		DownSql:  nil,
+	}
+
+	if len(strings.TrimSpace(downSqlScript)) > 0 {
+		migration.Down = SchemeHelperMigrationSqlFunc(downSqlScript)
+		migration.DownSql = &downSqlScript
+	}
+
+	return registry.helperRegister(migration)
+}
+
+func (registry *SchemeMigrationRegistry) RegisterFS(fileSystem fs.FS, dirName string) (err error) {
+	var (
+		dirEntryArray []fs.DirEntry
+		fileData      []byte
+		version       int64
+		versionArray  []int64
+		nameMap       = make(map[int64]string)
+		upMap         = make(map[int64]string)
+		downMap       = make(map[int64]string)
+	)
+
+	dirEntryArray, err = fs.ReadDir(fileSystem, dirName)
+	if err != nil {
+		return
+	}
+
+	for _, dirEntry := range dirEntryArray {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		fileMatch := schemeMigrationFileRegexp.FindStringSubmatch(dirEntry.Name())
+		if fileMatch == nil {
+			continue
+		}
+
+		version, err = strconv.ParseInt(fileMatch[1], 10, 64)
+		if err != nil {
+			return
+		}
+
+		fileData, err = fs.ReadFile(fileSystem, path.Join(dirName, dirEntry.Name()))
+		if err != nil {
+			return
+		}
+
+		if name, ok := nameMap[version]; ok && name != fileMatch[2] {
+			return fmt.Errorf("%w: version %d", ErrorSchemeMigrationIsDuplicated, version)
+		}
+
+		if _, ok := nameMap[version]; !ok {
+			versionArray = append(versionArray, version)
+			nameMap[version] = fileMatch[2]
+		}
+
+		if fileMatch[3] == ".down" {
+			downMap[version] = string(fileData)
+		} else {
+			upMap[version] = string(fileData)
+		}
+	}
+
+	for _, version = range versionArray {
+		err = registry.RegisterSql(version, nameMap[version], upMap[version], downMap[version])
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (registry *SchemeMigrationRegistry) GetMigration(version int64) *SchemeMigration {
+	registry.mutex <- true
+	defer func() {
+		<-registry.mutex
+	}()
+
+	return registry.migrationMap[version]
+}
+
+func (registry *SchemeMigrationRegistry) GetMigrationArray() []*SchemeMigration {
+	registry.mutex <- true
+	defer func() {
+		<-registry.mutex
+	}()
+
+	migrationArray := make([]*SchemeMigration, 0, len(registry.migrationMap))
+	for _, migration := range registry.migrationMap {
+		migrationArray = append(migrationArray, migration)
+	}
+
+	sort.Slice(migrationArray, func(i, j int) bool {
+		return migrationArray[i].Version < migrationArray[j].Version
+	})
+
+	return migrationArray
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperHistoryStepVersion(historyArray []SchemeHistoryStable) (stepVersion int64) {
+	for _, historyUnit := range historyArray {
+		if historyUnit.HistoryKind == SchemeHistoryKindStep && historyUnit.HistoryVersion > stepVersion {
+			stepVersion = historyUnit.HistoryVersion
+		}
+	}
+
+	return
+}
+
+func SchemeHelperMigrationChecksum(migrationSource string) string {
+	migrationHash := md5.Sum([]byte(migrationSource))
+	return hex.EncodeToString(migrationHash[:])
+}
+
+func schemeHelperMigrationFuncChecksum(version int64, name string) string {
+	return SchemeHelperMigrationChecksum(fmt.Sprintf("func:%d:%s", version, name))
+}
+
+func SchemeHelperMigrationSqlFunc(sqlScript string) func(*Transaction) error {
+	sqlStatementArray := SchemeHelperMigrationSqlSplit(sqlScript)
+
+	return func(transaction *Transaction) (err error) {
+		for _, sqlStatement := range sqlStatementArray {
+			err = transaction.Execute(NewBuilderRaw(sqlStatement))
+			if err != nil {
+				return
+			}
+		}
+
+		return
+	}
+}
+
+func SchemeHelperMigrationSqlSplit(sqlScript string) (sqlStatementArray []string) {
+	var (
+		sqlStatement   strings.Builder
+		quoteRune      rune
+		isComment      bool
+		isBlockComment bool
+		isBlockHint    bool
+		runeArray      = []rune(sqlScript)
+	)
+
+	for runeIndex := 0; runeIndex < len(runeArray); runeIndex++ {
+		runeUnit := runeArray[runeIndex]
+
+		switch {
+		case isComment:
+			if runeUnit == '\n' {
+				isComment = false
+				sqlStatement.WriteRune(runeUnit)
+			}
+			continue
+		case isBlockComment:
+			if runeUnit == '*' && runeIndex+1 < len(runeArray) && runeArray[runeIndex+1] == '/' {
+				isBlockComment = false
+				runeIndex++
+
+				if isBlockHint {
+					sqlStatement.WriteString("*/")
+				} else {
+					sqlStatement.WriteRune(' ')
+				}
+			} else if isBlockHint {
+				sqlStatement.WriteRune(runeUnit)
+			}
+			continue
+		case quoteRune == 0 && runeUnit == '/' && runeIndex+1 < len(runeArray) && runeArray[runeIndex+1] == '*':
+			isBlockComment = true
+			isBlockHint = runeIndex+2 < len(runeArray) && (runeArray[runeIndex+2] == '!' || runeArray[runeIndex+2] == '+')
+			runeIndex++
+
+			if isBlockHint {
+				sqlStatement.WriteString("/*")
+			}
+			continue
+		case quoteRune != 0:
+			if runeUnit == quoteRune {
+				quoteRune = 0
+			}
+		case runeUnit == '\'' || runeUnit == '"' || runeUnit == '`':
+			quoteRune = runeUnit
+		case runeUnit == '-' && runeIndex+1 < len(runeArray) && runeArray[runeIndex+1] == '-':
+			isComment = true
+			continue
+		case runeUnit == ';':
+			if trimmed := strings.TrimSpace(sqlStatement.String()); len(trimmed) > 0 {
+				sqlStatementArray = append(sqlStatementArray, trimmed)
+			}
+			sqlStatement.Reset()
+			continue
+		}
+
+		sqlStatement.WriteRune(runeUnit)
+	}
+
+	if trimmed := strings.TrimSpace(sqlStatement.String()); len(trimmed) > 0 {
+		sqlStatementArray = append(sqlStatementArray, trimmed)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewSchemeMigrationRegistry() *SchemeMigrationRegistry {
+	return &SchemeMigrationRegistry{
+		mutex:        make(chan interface{}, 1),
+		migrationMap: map[int64]*SchemeMigration{},
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,208 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testSchemeUserV1 struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+}
+
+type testSchemeUserV2 struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+	Mail string `sql:"NAME=mail | DEFAULT=''"`
+}
+
+type testSchemeTag struct {
+	Id    int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Label string `sql:"NAME=label"`
+}
+
+func testSchemeOpen(t *testing.T, databasePath string, schemeVersion int64, migrationRegistry *SchemeMigrationRegistry) (*Database, SchemeWithMigration) {
+	t.Helper()
+
+	scheme := NewSchemeDatabase("scheme", schemeVersion).(SchemeWithMigration)
+
+	if migrationRegistry != nil {
+		err := scheme.RegisterMigration(migrationRegistry)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	database, err := NewDatabase(NewTransportSimple("sqlite", databasePath), scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	return database, scheme
+}
+
+func testSchemeTagCount(t *testing.T, database *Database) int {
+	t.Helper()
+
+	table, err := NewTable("tags", testSchemeTag{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return len(response.([]testSchemeTag))
+}
+
+func testSchemeMigrationRegistry(t *testing.T) *SchemeMigrationRegistry {
+	t.Helper()
+
+	migrationRegistry := NewSchemeMigrationRegistry()
+
+	err := migrationRegistry.RegisterSql(1, "create_tags", "CREATE TABLE tags (id INTEGER PRIMARY KEY, label TEXT); /* seed; */ INSERT INTO tags VALUES (1, 'a');", "DROP TABLE tags;")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = migrationRegistry.RegisterFunc(2, "seed_tags", func(transaction *Transaction) error {
+		return transaction.Execute(NewBuilderRaw("INSERT INTO tags VALUES (2, 'b')"))
+	}, func(transaction *Transaction) error {
+		return transaction.Execute(NewBuilderRaw("DELETE FROM tags WHERE id = 2"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return migrationRegistry
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeMigrate(t *testing.T) {
+	database, scheme := testSchemeOpen(t, filepath.Join(t.TempDir(), "migrate.db"), 1, testSchemeMigrationRegistry(t))
+
+	if tagCount := testSchemeTagCount(t, database); tagCount != 2 {
+		t.Errorf("migrated table holds %d tags, want 2", tagCount)
+	}
+
+	historyArray, err := scheme.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if historyStepVersion := schemeHelperHistoryStepVersion(historyArray); historyStepVersion != 2 {
+		t.Errorf("history step version is %d, want 2", historyStepVersion)
+	}
+
+	err = scheme.Migrate()
+	if err != nil {
+		t.Errorf("repeated migrate returned %v", err)
+	}
+
+	if tagCount := testSchemeTagCount(t, database); tagCount != 2 {
+		t.Errorf("table holds %d tags after the repeated migrate, want 2", tagCount)
+	}
+}
+
+func TestSchemeMigrateTo(t *testing.T) {
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "migrate.db"), 1, testSchemeMigrationRegistry(t))
+
+	err := database.MigrateTo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tagCount := testSchemeTagCount(t, database); tagCount != 1 {
+		t.Errorf("table holds %d tags after reverting to 1, want 1", tagCount)
+	}
+
+	err = database.MigrateTo(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagTable, _ := NewTable("tags", testSchemeTag{})
+	if _, err = database.Query(NewBuilderSelect(tagTable)); err == nil {
+		t.Error("tags table still exists after reverting to 0")
+	}
+}
+
+func TestSchemeMigrateToIrreversible(t *testing.T) {
+	migrationRegistry := NewSchemeMigrationRegistry()
+
+	err := migrationRegistry.RegisterSql(1, "create_tags", "CREATE TABLE tags (id INTEGER PRIMARY KEY, label TEXT);", "DROP TABLE tags;")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = migrationRegistry.RegisterFunc(2, "seed_tags", func(transaction *Transaction) error {
+		return transaction.Execute(NewBuilderRaw("INSERT INTO tags VALUES (1, 'a')"))
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "irreversible.db"), 1, migrationRegistry)
+
+	err = database.MigrateTo(0)
+	if !errors.Is(err, ErrorSchemeMigrationIsIrreversible) {
+		t.Errorf("revert without a down returned %v, want an irreversible migration error", err)
+	}
+
+	if tagCount := testSchemeTagCount(t, database); tagCount != 1 {
+		t.Errorf("table holds %d tags after the rejected revert, want 1", tagCount)
+	}
+}
+
+func TestSchemeMigrateToTable(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "table.db")
+
+	databaseV1, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	_, err := databaseV1.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	databaseV2, _ := testSchemeOpen(t, databasePath, 2, nil)
+
+	_, err = databaseV2.RegisterTable("users", testSchemeUserV2{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = databaseV1.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Errorf("older scheme registering against a newer one returned %v", err)
+	}
+
+	err = databaseV2.MigrateTo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableV1, _ := NewTable("users", testSchemeUserV1{})
+
+	verify, err := databaseV2.Verify(tableV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verify.HasDrift() {
+		t.Errorf("reverted table drifts from version 1:\n%s", verify)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,668 @@
+package sqlctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	SchemePlanActionNone    = "none"
+	SchemePlanActionCreate  = "create"
+	SchemePlanActionMigrate = "migrate"
+)
+
+type SchemePlanColumn struct {
+	Name     string `json:"name"`
+	NameFrom string `json:"name_from,omitempty"`
+	Type     string `json:"type"`
+	TypeFrom string `json:"type_from,omitempty"`
+}
+
+type SchemePlanConstraint struct {
+	Column     string `json:"column"`
+	Constraint string `json:"constraint"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+type SchemePlanTable struct {
+	Table         string `json:"table"`
+	Action        string `json:"action"`
+	VersionRemote int64  `json:"version_remote"`
+	VersionLocal  int64  `json:"version_local"`
+
+	AddedArray      []SchemePlanColumn     `json:"added,omitempty"`
+	RemovedArray    []SchemePlanColumn     `json:"removed,omitempty"`
+	RenamedArray    []SchemePlanColumn     `json:"renamed,omitempty"`
+	RetypedArray    []SchemePlanColumn     `json:"retyped,omitempty"`
+	ConstraintArray []SchemePlanConstraint `json:"constraints,omitempty"`
+
+	SqlArray []string `json:"sql,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type SchemePlan struct {
+	TableArray []*SchemePlanTable `json:"tables"`
+}
+
+//--------------------------------------------------------------------------------//
+
+func (plan *SchemePlan) HasChange() bool {
+	for _, planTable := range plan.TableArray {
+		if planTable.Action != SchemePlanActionNone {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (plan *SchemePlan) HasError() bool {
+	for _, planTable := range plan.TableArray {
+		if len(planTable.Error) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (plan *SchemePlan) Json() ([]byte, error) {
+	return json.MarshalIndent(plan, "", "  ")
+}
+
+func (plan *SchemePlan) String() string {
+	var planText strings.Builder
+
+	for _, planTable := range plan.TableArray {
+		fmt.Fprintf(&planText, "table %s: %s (version %d -> %d)\n", planTable.Table, planTable.Action, planTable.VersionRemote, planTable.VersionLocal)
+
+		for _, planColumn := range planTable.AddedArray {
+			fmt.Fprintf(&planText, "  + %s %s\n", planColumn.Name, planColumn.Type)
+		}
+
+		for _, planColumn := range planTable.RemovedArray {
+			fmt.Fprintf(&planText, "  - %s %s\n", planColumn.Name, planColumn.Type)
+		}
+
+		for _, planColumn := range planTable.RenamedArray {
+			fmt.Fprintf(&planText, "  > %s -> %s\n", planColumn.NameFrom, planColumn.Name)
+		}
+
+		for _, planColumn := range planTable.RetypedArray {
+			fmt.Fprintf(&planText, "  ~ %s: %s -> %s\n", planColumn.Name, planColumn.TypeFrom, planColumn.Type)
+		}
+
+		for _, planConstraint := range planTable.ConstraintArray {
+			fmt.Fprintf(&planText, "  ! %s: %s %s -> %s\n", planConstraint.Column, planConstraint.Constraint, planConstraint.From, planConstraint.To)
+		}
+
+		for _, planSql := range planTable.SqlArray {
+			fmt.Fprintf(&planText, "  sql: %s\n", planSql)
+		}
+
+		if len(planTable.Error) > 0 {
+			fmt.Fprintf(&planText, "  error: %s\n", planTable.Error)
+		}
+	}
+
+	return planText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperNeedExport(fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (bool, error) {
+	if fieldMapRemote == nil {
+		return true, nil
+	}
+
+	if fieldMapRemote.SchemeVersion > fieldMapLocal.SchemeVersion {
+		return false, nil
+	}
+
+	if fieldMapRemote.SchemeHeader != fieldMapLocal.SchemeHeader || fieldMapRemote.SchemeVersion < fieldMapLocal.SchemeVersion || fieldMapRemote.GetHash() != fieldMapLocal.GetHash() {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func SchemeHelperMigrationFieldMatch(table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (fieldRemoteNameArray []string, fieldLocalNameArray []string) {
+	var (
+		fieldRemoteArray = schemeHelperPlanFieldArray(fieldMapRemote)
+		fieldLocalArray  = schemeHelperPlanFieldArray(fieldMapLocal)
+		fieldRemoteMatch = map[string]string{}
+		fieldLocalMatch  = map[string]string{}
+	)
+
+	for _, fieldMatch := range []func(*SchemeStorageStable, *SchemeStorageStable) bool{
+		func(fieldRemoteUnit *SchemeStorageStable, fieldLocalUnit *SchemeStorageStable) bool {
+			return fieldRemoteUnit.RemoteFieldName == fieldLocalUnit.RemoteFieldName
+		},
+		func(fieldRemoteUnit *SchemeStorageStable, fieldLocalUnit *SchemeStorageStable) bool {
+			if table == nil {
+				return false
+			}
+
+			tableField := table.GetFieldBySqlName(fieldLocalUnit.RemoteFieldName)
+			if tableField == nil {
+				return false
+			}
+
+			for _, renamedFrom := range tableField.RenamedFrom() {
+				if fieldRemoteUnit.RemoteFieldName == renamedFrom {
+					return true
+				}
+			}
+
+			return false
+		},
+	} {
+		for _, fieldLocalUnit := range fieldLocalArray {
+			if _, ok := fieldLocalMatch[fieldLocalUnit.RemoteFieldName]; ok {
+				continue
+			}
+
+			for _, fieldRemoteUnit := range fieldRemoteArray {
+				if _, ok := fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName]; ok {
+					continue
+				}
+
+				if fieldMatch(fieldRemoteUnit, fieldLocalUnit) {
+					fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName] = fieldLocalUnit.RemoteFieldName
+					fieldLocalMatch[fieldLocalUnit.RemoteFieldName] = fieldRemoteUnit.RemoteFieldName
+					break
+				}
+			}
+		}
+	}
+
+	for _, fieldRemoteUnit := range fieldRemoteArray {
+		if fieldLocalName, ok := fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName]; ok {
+			fieldRemoteNameArray = append(fieldRemoteNameArray, fieldRemoteUnit.RemoteFieldName)
+			fieldLocalNameArray = append(fieldLocalNameArray, fieldLocalName)
+		}
+	}
+
+	return
+}
+
+func SchemeHelperMigrationFieldDrop(table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (fieldDropNameArray []string) {
+	fieldRemoteNameArray, _ := SchemeHelperMigrationFieldMatch(table, fieldMapRemote, fieldMapLocal)
+	fieldRemoteMatch := map[string]bool{}
+
+	for _, fieldRemoteName := range fieldRemoteNameArray {
+		fieldRemoteMatch[fieldRemoteName] = true
+	}
+
+	for _, fieldRemoteUnit := range schemeHelperPlanFieldArray(fieldMapRemote) {
+		if !fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName] {
+			fieldDropNameArray = append(fieldDropNameArray, fieldRemoteUnit.RemoteFieldName)
+		}
+	}
+
+	return
+}
+
+func SchemeHelperMigrationBuilderArray(sqlDialect string, table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) ([]Builder, error) {
+	fieldRemoteNameArray, fieldLocalNameArray := SchemeHelperMigrationFieldMatch(table, fieldMapRemote, fieldMapLocal)
+	if len(fieldRemoteNameArray) == 0 {
+		return nil, ErrorSchemeMigrationIsLimitedByVersion
+	}
+
+	indexDropArray, indexCreateArray := schemeHelperMigrationIndexArray(table, fieldMapRemote, fieldMapLocal, fieldRemoteNameArray, fieldLocalNameArray)
+
+	builderArray, ok := schemeHelperMigrationAlterArray(sqlDialect, table, fieldMapRemote, fieldMapLocal, fieldRemoteNameArray, fieldLocalNameArray)
+	if ok {
+		builderArray = append(indexDropArray, builderArray...)
+		return append(builderArray, indexCreateArray...), nil
+	}
+
+	migrationName := fmt.Sprintf("_migration_%s", table.GetSqlName())
+
+	copyBuilder := NewBuilderCopy(table).CopyName(migrationName).From(table.GetSqlName())
+	for fieldIndex := range fieldRemoteNameArray {
+		copyBuilder.Field(fieldRemoteNameArray[fieldIndex], fieldLocalNameArray[fieldIndex])
+	}
+
+	builderArray = []Builder{
+		NewBuilderCreate(table).CreateName(migrationName).IfNotExists(false),
+		copyBuilder,
+		NewBuilderDrop(table),
+		NewBuilderAlter(table).AlterName(migrationName).RenameTo(table.GetSqlName()),
+	}
+
+	for _, indexName := range table.GetIndexNameArray() {
+		builderArray = append(builderArray, NewBuilderCreateIndex(table, indexName))
+	}
+
+	return builderArray, nil
+}
+
+func schemeHelperExportPrepare(fieldMapLocal *SchemeStorageTable, migrationVersion int64, updatedAt int64) {
+	fieldMapLocal.SchemeHeader = SchemeHeaderStable
+	fieldMapLocal.MigrationVersion = migrationVersion
+	fieldMapLocal.UpdatedAt = updatedAt
+
+	for _, indexUnit := range fieldMapLocal.IndexMap {
+		indexUnit.SchemeHeader = SchemeHeaderStable
+		indexUnit.SchemeVersion = fieldMapLocal.SchemeVersion
+		indexUnit.RemoteTableName = fieldMapLocal.RemoteTableName
+	}
+
+	for _, fieldUnit := range fieldMapLocal.FieldMap {
+		fieldUnit.SchemeHeader = SchemeHeaderStable
+		fieldUnit.SchemeVersion = fieldMapLocal.SchemeVersion
+	}
+}
+
+func SchemeHelperCreateBuilderArray(table *Table) []Builder {
+	builderArray := []Builder{NewBuilderCreate(table).IfNotExists(true)}
+
+	for _, indexName := range table.GetIndexNameArray() {
+		builderArray = append(builderArray, NewBuilderCreateIndex(table, indexName).IfNotExists(true))
+	}
+
+	return builderArray
+}
+
+func schemeHelperMigrationIndexArray(table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable, fieldRemoteNameArray []string, fieldLocalNameArray []string) (dropArray []Builder, createArray []Builder) {
+	fieldRemoteMatch := map[string]string{}
+
+	for fieldIndex := range fieldRemoteNameArray {
+		fieldRemoteMatch[fieldRemoteNameArray[fieldIndex]] = fieldLocalNameArray[fieldIndex]
+	}
+
+	indexRemoteMap := schemeHelperMigrationIndex(fieldMapRemote, fieldRemoteMatch)
+	indexLocalMap := schemeHelperMigrationIndex(fieldMapLocal, nil)
+
+	for _, indexName := range schemeHelperIndexNameArray(indexRemoteMap) {
+		if indexLocalMap[indexName] != indexRemoteMap[indexName] {
+			dropArray = append(dropArray, NewBuilderDropIndex(table, schemeHelperIndexGroup(fieldMapRemote.RemoteTableName, indexName)))
+		}
+	}
+
+	for _, indexName := range schemeHelperIndexNameArray(indexLocalMap) {
+		if indexLocalMap[indexName] != indexRemoteMap[indexName] {
+			createArray = append(createArray, NewBuilderCreateIndex(table, schemeHelperIndexGroup(fieldMapLocal.RemoteTableName, indexName)))
+		}
+	}
+
+	return
+}
+
+func schemeHelperMigrationIndex(fieldMap *SchemeStorageTable, fieldNameMap map[string]string) map[string]string {
+	indexMap := map[string]string{}
+
+	for _, indexUnit := range fieldMap.IndexMap {
+		if indexUnit.IndexKind != SchemeIndexKindIndex {
+			continue
+		}
+
+		indexColumnArray := strings.Split(indexUnit.IndexColumn, ",")
+
+		for columnIndex, indexColumn := range indexColumnArray {
+			fieldName, fieldDesc := strings.CutSuffix(strings.TrimSpace(indexColumn), " DESC")
+
+			if fieldNameMap != nil {
+				fieldName = fieldNameMap[fieldName]
+			}
+
+			if fieldDesc {
+				fieldName = fmt.Sprintf("%s DESC", fieldName)
+			}
+
+			indexColumnArray[columnIndex] = fieldName
+		}
+
+		indexMap[indexUnit.IndexName] = fmt.Sprintf("%s:%s", strings.Join(indexColumnArray, ","), schemeHelperPlanString(indexUnit.IndexWhere))
+	}
+
+	return indexMap
+}
+
+func schemeHelperIndexNameArray(indexMap map[string]string) (indexNameArray []string) {
+	for indexName := range indexMap {
+		indexNameArray = append(indexNameArray, indexName)
+	}
+
+	sort.Strings(indexNameArray)
+	return
+}
+
+func schemeHelperIndexGroup(tableName string, indexName string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(indexName, fmt.Sprintf("%s_", tableName)), "_ix")
+}
+
+func schemeHelperMigrationAlterArray(sqlDialect string, table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable, fieldRemoteNameArray []string, fieldLocalNameArray []string) (builderArray []Builder, ok bool) {
+	var (
+		fieldRemoteMatch = map[string]string{}
+		fieldLocalMatch  = map[string]string{}
+		renameArray      []Builder
+		modifyArray      []Builder
+	)
+
+	switch sqlDialect {
+	case "sqlite", "mysql", "":
+	default:
+		return nil, false
+	}
+
+	for fieldIndex := range fieldRemoteNameArray {
+		fieldRemoteMatch[fieldRemoteNameArray[fieldIndex]] = fieldLocalNameArray[fieldIndex]
+		fieldLocalMatch[fieldLocalNameArray[fieldIndex]] = fieldRemoteNameArray[fieldIndex]
+	}
+
+	if schemeHelperMigrationConstraint(fieldMapRemote, fieldRemoteMatch) != schemeHelperMigrationConstraint(fieldMapLocal, nil) {
+		return nil, false
+	}
+
+	for _, fieldRemoteUnit := range schemeHelperPlanFieldArray(fieldMapRemote) {
+		fieldLocalName, matched := fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName]
+		if !matched {
+			if fieldRemoteUnit.FieldValueCheck != nil {
+				return nil, false
+			}
+
+			builderArray = append(builderArray, NewBuilderAlter(table).DropColumn(fieldRemoteUnit.RemoteFieldName))
+			continue
+		}
+
+		fieldLocalUnit := fieldMapLocal.FieldMap[fieldLocalName]
+
+		if fieldLocalName != fieldRemoteUnit.RemoteFieldName {
+			if _, collided := fieldRemoteMatch[fieldLocalName]; collided || fieldRemoteUnit.FieldValueCheck != nil {
+				return nil, false
+			}
+		}
+
+		if fieldLocalUnit.RemoteFieldType != fieldRemoteUnit.RemoteFieldType ||
+			fieldLocalUnit.FieldIsNotNull != fieldRemoteUnit.FieldIsNotNull ||
+			schemeHelperPlanString(fieldLocalUnit.FieldValueDefault) != schemeHelperPlanString(fieldRemoteUnit.FieldValueDefault) {
+			if sqlDialect == "sqlite" {
+				return nil, false
+			}
+
+			modifyArray = append(modifyArray, NewBuilderAlter(table).ModifyColumn(fieldRemoteUnit.RemoteFieldName, fieldLocalName))
+		} else if fieldLocalName != fieldRemoteUnit.RemoteFieldName {
+			renameArray = append(renameArray, NewBuilderAlter(table).RenameColumn(fieldRemoteUnit.RemoteFieldName, fieldLocalName))
+		}
+	}
+
+	builderArray = append(builderArray, renameArray...)
+	builderArray = append(builderArray, modifyArray...)
+
+	for _, fieldLocalUnit := range schemeHelperPlanFieldArray(fieldMapLocal) {
+		if _, matched := fieldLocalMatch[fieldLocalUnit.RemoteFieldName]; matched {
+			continue
+		}
+
+		if fieldLocalUnit.FieldValueCheck != nil || (fieldLocalUnit.FieldIsNotNull && fieldLocalUnit.FieldValueDefault == nil) {
+			return nil, false
+		}
+
+		builderArray = append(builderArray, NewBuilderAlter(table).AddColumn(fieldLocalUnit.RemoteFieldName))
+	}
+
+	return builderArray, true
+}
+
+func schemeHelperMigrationConstraint(fieldMap *SchemeStorageTable, fieldNameMap map[string]string) string {
+	var constraintArray []string
+
+	for _, fieldUnit := range schemeHelperPlanFieldArray(fieldMap) {
+		fieldName := fieldUnit.RemoteFieldName
+		if fieldNameMap != nil {
+			fieldName = fieldNameMap[fieldUnit.RemoteFieldName]
+		}
+
+		if fieldUnit.FieldIsPrimaryKey {
+			constraintArray = append(constraintArray, fmt.Sprintf("pk:%s", fieldName))
+		}
+
+		if fieldUnit.FieldIsAutoIncrement {
+			constraintArray = append(constraintArray, fmt.Sprintf("ai:%s", fieldName))
+		}
+
+		if fieldUnit.FieldInUniqueGroup != nil {
+			constraintArray = append(constraintArray, fmt.Sprintf("uq:%s:%s", *fieldUnit.FieldInUniqueGroup, fieldName))
+		}
+
+		if fieldUnit.FieldValueCheck != nil && len(fieldName) > 0 {
+			constraintArray = append(constraintArray, fmt.Sprintf("ck:%s:%s", fieldName, *fieldUnit.FieldValueCheck))
+		}
+	}
+
+	for _, indexUnit := range fieldMap.IndexMap {
+		if indexUnit.IndexKind != SchemeIndexKindForeignKey {
+			continue
+		}
+
+		fieldName := indexUnit.IndexColumn
+		if fieldNameMap != nil {
+			fieldName = fieldNameMap[indexUnit.IndexColumn]
+		}
+
+		constraintArray = append(constraintArray, fmt.Sprintf("fk:%s:%s", fieldName, schemeHelperPlanReference(fieldMap, indexUnit.IndexColumn)))
+	}
+
+	sort.Strings(constraintArray)
+	return strings.Join(constraintArray, "\n")
+}
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperPlanTable(sqlDialect string, table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) *SchemePlanTable {
+	var (
+		builderArray []Builder
+		needExport   bool
+		err          error
+	)
+
+	planTable := &SchemePlanTable{
+		Table:        fieldMapLocal.RemoteTableName,
+		Action:       SchemePlanActionNone,
+		VersionLocal: fieldMapLocal.SchemeVersion,
+	}
+
+	if fieldMapRemote != nil {
+		planTable.VersionRemote = fieldMapRemote.SchemeVersion
+	}
+
+	defer func() {
+		if err != nil {
+			planTable.Error = err.Error()
+		}
+	}()
+
+	needExport, err = SchemeHelperNeedExport(fieldMapRemote, fieldMapLocal)
+	if err != nil || !needExport {
+		return planTable
+	}
+
+	if fieldMapRemote == nil {
+		planTable.Action = SchemePlanActionCreate
+		builderArray = SchemeHelperCreateBuilderArray(table)
+
+		for _, fieldLocalUnit := range schemeHelperPlanFieldArray(fieldMapLocal) {
+			planTable.AddedArray = append(planTable.AddedArray, SchemePlanColumn{Name: fieldLocalUnit.RemoteFieldName, Type: fieldLocalUnit.RemoteFieldType})
+		}
+	} else {
+		planTable.Action = SchemePlanActionMigrate
+		schemeHelperPlanColumn(planTable, table, fieldMapRemote, fieldMapLocal)
+
+		builderArray, err = SchemeHelperMigrationBuilderArray(sqlDialect, table, fieldMapRemote, fieldMapLocal)
+		if err != nil {
+			return planTable
+		}
+	}
+
+	planTable.SqlArray, err = SchemeHelperPlanSqlArray(sqlDialect, builderArray)
+	return planTable
+}
+
+func SchemeHelperPlanSqlArray(sqlDialect string, builderArray []Builder) (sqlArray []string, err error) {
+	for _, builderUnit := range builderArray {
+		var (
+			builderString string
+			builderOption []interface{}
+		)
+
+		switch v := builderUnit.(type) {
+		case BuilderWithDialect:
+			v.SetDialect(sqlDialect)
+		}
+
+		builderString, builderOption, err = builderUnit.Build()
+		if err != nil {
+			return
+		}
+
+		builderString, err = SchemeHelperPlanInline(builderString, builderOption)
+		if err != nil {
+			return
+		}
+
+		sqlArray = append(sqlArray, builderString)
+	}
+
+	return
+}
+
+func SchemeHelperPlanInline(builderString string, builderOption []interface{}) (string, error) {
+	var (
+		planText    strings.Builder
+		optionIndex int
+		quoteRune   rune
+	)
+
+	for _, builderRune := range builderString {
+		switch {
+		case quoteRune != 0:
+			if builderRune == quoteRune {
+				quoteRune = 0
+			}
+		case builderRune == '\'' || builderRune == '`' || builderRune == '"':
+			quoteRune = builderRune
+		case builderRune == '?' && optionIndex < len(builderOption):
+			optionString, err := SqlFieldValuerToString(reflect.ValueOf(builderOption[optionIndex]))
+			if err != nil {
+				return "", err
+			}
+
+			planText.WriteString(optionString)
+			optionIndex++
+			continue
+		}
+
+		planText.WriteRune(builderRune)
+	}
+
+	return planText.String(), nil
+}
+
+func schemeHelperPlanFieldArray(fieldMap *SchemeStorageTable) (fieldArray []*SchemeStorageStable) {
+	for _, fieldUnit := range fieldMap.FieldMap {
+		fieldArray = append(fieldArray, fieldUnit)
+	}
+
+	sort.Slice(fieldArray, func(i, j int) bool {
+		return fieldArray[i].FieldIndex < fieldArray[j].FieldIndex
+	})
+
+	return
+}
+
+func schemeHelperPlanColumn(planTable *SchemePlanTable, table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) {
+	fieldRemoteNameArray, fieldLocalNameArray := SchemeHelperMigrationFieldMatch(table, fieldMapRemote, fieldMapLocal)
+	fieldRemoteMatch := map[string]string{}
+	fieldLocalMatch := map[string]string{}
+
+	for fieldIndex := range fieldRemoteNameArray {
+		fieldRemoteMatch[fieldRemoteNameArray[fieldIndex]] = fieldLocalNameArray[fieldIndex]
+		fieldLocalMatch[fieldLocalNameArray[fieldIndex]] = fieldRemoteNameArray[fieldIndex]
+	}
+
+	for _, fieldRemoteUnit := range schemeHelperPlanFieldArray(fieldMapRemote) {
+		fieldLocalName, ok := fieldRemoteMatch[fieldRemoteUnit.RemoteFieldName]
+		if !ok {
+			planTable.RemovedArray = append(planTable.RemovedArray, SchemePlanColumn{Name: fieldRemoteUnit.RemoteFieldName, Type: fieldRemoteUnit.RemoteFieldType})
+			continue
+		}
+
+		fieldLocalUnit := fieldMapLocal.FieldMap[fieldLocalName]
+
+		if fieldLocalName != fieldRemoteUnit.RemoteFieldName {
+			planTable.RenamedArray = append(planTable.RenamedArray, SchemePlanColumn{Name: fieldLocalName, NameFrom: fieldRemoteUnit.RemoteFieldName, Type: fieldLocalUnit.RemoteFieldType})
+		}
+
+		if fieldLocalUnit.RemoteFieldType != fieldRemoteUnit.RemoteFieldType {
+			planTable.RetypedArray = append(planTable.RetypedArray, SchemePlanColumn{Name: fieldLocalName, Type: fieldLocalUnit.RemoteFieldType, TypeFrom: fieldRemoteUnit.RemoteFieldType})
+		}
+
+		for _, constraintUnit := range []struct {
+			name     string
+			from, to string
+		}{
+			{"PRIMARY_KEY", fmt.Sprint(fieldRemoteUnit.FieldIsPrimaryKey), fmt.Sprint(fieldLocalUnit.FieldIsPrimaryKey)},
+			{"AUTO_INCREMENT", fmt.Sprint(fieldRemoteUnit.FieldIsAutoIncrement), fmt.Sprint(fieldLocalUnit.FieldIsAutoIncrement)},
+			{"NOT_NULL", fmt.Sprint(fieldRemoteUnit.FieldIsNotNull), fmt.Sprint(fieldLocalUnit.FieldIsNotNull)},
+			{"UNIQUE_GROUP", schemeHelperPlanString(fieldRemoteUnit.FieldInUniqueGroup), schemeHelperPlanString(fieldLocalUnit.FieldInUniqueGroup)},
+			{"DEFAULT", schemeHelperPlanString(fieldRemoteUnit.FieldValueDefault), schemeHelperPlanString(fieldLocalUnit.FieldValueDefault)},
+			{"CHECK", schemeHelperPlanString(fieldRemoteUnit.FieldValueCheck), schemeHelperPlanString(fieldLocalUnit.FieldValueCheck)},
+			{"REFERENCES", schemeHelperPlanReference(fieldMapRemote, fieldRemoteUnit.RemoteFieldName), schemeHelperPlanReference(fieldMapLocal, fieldLocalName)},
+		} {
+			if constraintUnit.from != constraintUnit.to {
+				planTable.ConstraintArray = append(planTable.ConstraintArray, SchemePlanConstraint{
+					Column:     fieldLocalName,
+					Constraint: constraintUnit.name,
+					From:       constraintUnit.from,
+					To:         constraintUnit.to,
+				})
+			}
+		}
+	}
+
+	for _, fieldLocalUnit := range schemeHelperPlanFieldArray(fieldMapLocal) {
+		if _, ok := fieldLocalMatch[fieldLocalUnit.RemoteFieldName]; !ok {
+			planTable.AddedArray = append(planTable.AddedArray, SchemePlanColumn{Name: fieldLocalUnit.RemoteFieldName, Type: fieldLocalUnit.RemoteFieldType})
+		}
+	}
+}
+
+func schemeHelperPlanReference(fieldMap *SchemeStorageTable, fieldName string) string {
+	for _, indexUnit := range fieldMap.IndexMap {
+		if indexUnit.IndexKind != SchemeIndexKindForeignKey || indexUnit.IndexColumn != fieldName {
+			continue
+		}
+
+		reference := fmt.Sprintf("%s(%s)", schemeHelperPlanString(indexUnit.ReferenceTable), schemeHelperPlanString(indexUnit.ReferenceColumn))
+
+		if indexUnit.ReferenceOnDelete != nil {
+			reference += fmt.Sprintf(" ON DELETE %s", *indexUnit.ReferenceOnDelete)
+		}
+
+		if indexUnit.ReferenceOnUpdate != nil {
+			reference += fmt.Sprintf(" ON UPDATE %s", *indexUnit.ReferenceOnUpdate)
+		}
+
+		return reference
+	}
+
+	return "NULL"
+}
+
+func schemeHelperPlanString(value *string) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	return *value
+}
+
+//--------------------------------------------------------------------------------//
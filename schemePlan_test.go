@@ -0,0 +1,202 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testSchemeUserRenamed struct {
+	Id       int64  `sql:"NAME=id | PRIMARY_KEY"`
+	FullName string `sql:"NAME=full_name | RENAMED_FROM=name"`
+}
+
+type testSchemeUserRetitled struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=title | DEFAULT=''"`
+}
+
+type testSchemeUserRetyped struct {
+	Id   int64 `sql:"NAME=id | PRIMARY_KEY"`
+	Name int64 `sql:"NAME=name | DEFAULT=0"`
+}
+
+func testSchemeAlterSql(t *testing.T, sqlDialect string, structRemote interface{}, structLocal interface{}) string {
+	t.Helper()
+
+	tableRemote, err := NewTable("users", structRemote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableLocal, err := NewTable("users", structLocal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldMapRemote, err := SchemeHelperTableToFieldMap(SchemeHeaderV2, 1, tableRemote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldMapLocal, err := SchemeHelperTableToFieldMap(SchemeHeaderV2, 2, tableLocal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builderArray, err := SchemeHelperMigrationBuilderArray(sqlDialect, tableLocal, fieldMapRemote, fieldMapLocal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlArray, err := SchemeHelperPlanSqlArray(sqlDialect, builderArray)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Join(sqlArray, ";\n")
+}
+
+func testSchemeUserOpen(t *testing.T, databasePath string) {
+	t.Helper()
+
+	database, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	table, err := database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testSchemeUserV1{Id: 1, Name: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemePlan(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "plan.db")
+
+	databaseV1, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	_, err := databaseV1.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, schemeV2 := testSchemeOpen(t, databasePath, 2, nil)
+
+	tableV2, _ := NewTable("users", testSchemeUserV2{})
+
+	plan, err := schemeV2.Plan(tableV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.HasChange() || plan.HasError() || len(plan.TableArray) != 1 {
+		t.Fatalf("plan is\n%s\nwant a single migrate without errors", plan)
+	}
+
+	planTable := plan.TableArray[0]
+	if planTable.Action != SchemePlanActionMigrate || len(planTable.AddedArray) != 1 || planTable.AddedArray[0].Name != "mail" {
+		t.Errorf("plan is\n%s\nwant mail added to users", plan)
+	}
+
+	if len(planTable.SqlArray) == 0 {
+		t.Error("plan has no sql")
+	}
+
+	tableV1, _ := NewTable("users", testSchemeUserV1{})
+
+	verify, err := databaseV1.Verify(tableV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verify.HasDrift() {
+		t.Errorf("plan changed the database:\n%s", verify)
+	}
+}
+
+func TestSchemeAlter(t *testing.T) {
+	for _, testCase := range []struct {
+		sqlDialect   string
+		structRemote interface{}
+		structLocal  interface{}
+		sqlExpected  string
+	}{
+		{"sqlite", testSchemeUserV1{}, testSchemeUserV2{}, "ADD COLUMN"},
+		{"sqlite", testSchemeUserV1{}, testSchemeUserRenamed{}, "RENAME COLUMN `name` TO `full_name`"},
+		{"sqlite", testSchemeUserV1{}, testSchemeUserRetyped{}, "_migration_users"},
+		{"mysql", testSchemeUserV1{}, testSchemeUserRetyped{}, "CHANGE COLUMN `name`"},
+	} {
+		sqlString := testSchemeAlterSql(t, testCase.sqlDialect, testCase.structRemote, testCase.structLocal)
+
+		if !strings.Contains(sqlString, testCase.sqlExpected) {
+			t.Errorf("%s migration to %T is\n%s\nwant %s", testCase.sqlDialect, testCase.structLocal, sqlString, testCase.sqlExpected)
+		}
+
+		if testCase.sqlExpected != "_migration_users" && strings.Contains(sqlString, "_migration_users") {
+			t.Errorf("%s migration to %T copies the table:\n%s", testCase.sqlDialect, testCase.structLocal, sqlString)
+		}
+	}
+}
+
+func TestSchemeRenamedFrom(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "renamed.db")
+
+	testSchemeUserOpen(t, databasePath)
+
+	database, _ := testSchemeOpen(t, databasePath, 2, nil)
+
+	table, err := database.RegisterTable("users", testSchemeUserRenamed{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userArray := response.([]testSchemeUserRenamed); len(userArray) != 1 || userArray[0].FullName != "a" {
+		t.Errorf("renamed table holds %v, want the name carried into full_name", userArray)
+	}
+}
+
+func TestSchemeDropPopulatedColumn(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "drop.db")
+
+	testSchemeUserOpen(t, databasePath)
+
+	database, scheme := testSchemeOpen(t, databasePath, 2, nil)
+
+	_, err := database.RegisterTable("users", testSchemeUserRetitled{})
+	if !errors.Is(err, ErrorSchemeMigrationDropsPopulatedColumn) {
+		t.Fatalf("renaming the column without RENAMED_FROM returned %v, want a populated column drop error", err)
+	}
+
+	scheme.(*schemeDatabase).AllowColumnDrop(true)
+
+	table, err := database.RegisterTable("users", testSchemeUserRetitled{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userArray := response.([]testSchemeUserRetitled); len(userArray) != 1 || userArray[0].Name != "" {
+		t.Errorf("retitled table holds %v, want the name dropped instead of matched by Go name", userArray)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,464 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+type schemeStorageDatabase struct {
+	storageName        string
+	storageHeaderTable *Table
+	storageV1Table     *Table
+	storageStableTable *Table
+	storageTableTable  *Table
+	storageIndexTable  *Table
+	storagePrepared    bool
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageDatabase) GetName() string {
+	return storage.storageName
+}
+
+func (storage *schemeStorageDatabase) GetTableNameArray() []string {
+	return []string{
+		storage.storageStableTable.GetSqlName(),
+		storage.storageTableTable.GetSqlName(),
+		storage.storageIndexTable.GetSqlName(),
+	}
+}
+
+func (storage *schemeStorageDatabase) IsReadOnly() bool {
+	return false
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageDatabase) prepare(transaction *Transaction) (err error) {
+	if storage.storagePrepared {
+		return
+	}
+
+	for _, storageTable := range []*Table{storage.storageStableTable, storage.storageTableTable, storage.storageIndexTable} {
+		err = transaction.Execute(NewBuilderCreate(storageTable).IfNotExists(true))
+		if err != nil {
+			return
+		}
+	}
+
+	storage.storagePrepared = true
+	return
+}
+
+func (storage *schemeStorageDatabase) reset(transaction *Transaction) (err error) {
+	storage.storagePrepared = false
+
+	err = storage.prepare(transaction)
+	if err != nil {
+		return
+	}
+
+	for _, storageTable := range []*Table{storage.storageStableTable, storage.storageTableTable, storage.storageIndexTable} {
+		err = transaction.Execute(NewBuilderDelete(storageTable))
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) importV1(transaction *Transaction) (storageRemote map[string]*SchemeStorageTable, err error) {
+	var (
+		responseInterface interface{}
+		responseArray     []SchemeStorageV1
+		ok                bool
+	)
+
+	storageRemote = make(map[string]*SchemeStorageTable)
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(storage.storageV1Table).WhereCondition(NewConditionEqual("scheme_header", SchemeHeaderV1)))
+	if err != nil {
+		return
+	}
+
+	responseArray, ok = responseInterface.([]SchemeStorageV1)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedStruct
+	}
+
+	for _, storageField := range responseArray {
+		if storageRemote[storageField.RemoteTableName] == nil {
+			storageRemote[storageField.RemoteTableName] = &SchemeStorageTable{
+				LocalTableName:  storageField.LocalTableName,
+				RemoteTableName: storageField.RemoteTableName,
+				SchemeHeader:    storageField.SchemeHeader,
+				SchemeVersion:   storageField.SchemeVersion,
+				FieldMap:        map[string]*SchemeStorageStable{},
+			}
+		}
+
+		storageRemote[storageField.RemoteTableName].FieldMap[storageField.RemoteFieldName] = &SchemeStorageStable{
+			SchemeHeader:         storageField.SchemeHeader,
+			SchemeVersion:        storageField.SchemeVersion,
+			LocalTableName:       storageField.LocalTableName,
+			RemoteTableName:      storageField.RemoteTableName,
+			LocalFieldName:       storageField.LocalFieldName,
+			RemoteFieldName:      storageField.RemoteFieldName,
+			LocalFieldType:       storageField.LocalFieldType,
+			RemoteFieldType:      storageField.RemoteFieldType,
+			FieldIndex:           storageField.FieldIndex,
+			FieldIsPrimaryKey:    storageField.FieldIsPrimaryKey,
+			FieldIsAutoIncrement: storageField.FieldIsAutoIncrement,
+			FieldIsNotNull:       storageField.FieldIsNotNull,
+			FieldInUniqueGroup:   storageField.FieldInUniqueGroup,
+			FieldValueDefault:    storageField.FieldValueDefault,
+			FieldValueCheck:      storageField.FieldValueCheck,
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) importV2(transaction *Transaction) (storageRemote map[string]*SchemeStorageTable, err error) {
+	var (
+		responseInterface interface{}
+		fieldArray        []SchemeStorageStable
+		tableArray        []SchemeStorageTableStable
+		indexArray        []SchemeStorageIndexStable
+		ok                bool
+	)
+
+	storageRemote = make(map[string]*SchemeStorageTable)
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(storage.storageStableTable).WhereCondition(NewConditionEqual("scheme_header", SchemeHeaderV2)))
+	if err != nil {
+		return
+	}
+
+	fieldArray, ok = responseInterface.([]SchemeStorageStable)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedStruct
+	}
+
+	for _, storageField := range fieldArray {
+		if storageRemote[storageField.RemoteTableName] == nil {
+			storageRemote[storageField.RemoteTableName] = &SchemeStorageTable{
+				LocalTableName:  storageField.LocalTableName,
+				RemoteTableName: storageField.RemoteTableName,
+				SchemeHeader:    storageField.SchemeHeader,
+				SchemeVersion:   storageField.SchemeVersion,
+				FieldMap:        map[string]*SchemeStorageStable{},
+				IndexMap:        map[string]*SchemeStorageIndexStable{},
+			}
+		}
+
+		storageFieldUnit := storageField
+		storageRemote[storageField.RemoteTableName].FieldMap[storageField.RemoteFieldName] = &storageFieldUnit
+	}
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(storage.storageTableTable).WhereCondition(NewConditionEqual("scheme_header", SchemeHeaderV2)))
+	if err != nil {
+		return
+	}
+
+	tableArray, ok = responseInterface.([]SchemeStorageTableStable)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedStruct
+	}
+
+	for _, storageTableUnit := range tableArray {
+		if storageTable := storageRemote[storageTableUnit.RemoteTableName]; storageTable != nil {
+			storageTable.TableOption = storageTableUnit.TableOption
+			storageTable.MigrationVersion = storageTableUnit.MigrationVersion
+			storageTable.UpdatedAt = storageTableUnit.UpdatedAt
+		}
+	}
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(storage.storageIndexTable).WhereCondition(NewConditionEqual("scheme_header", SchemeHeaderV2)))
+	if err != nil {
+		return
+	}
+
+	indexArray, ok = responseInterface.([]SchemeStorageIndexStable)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedStruct
+	}
+
+	for _, storageIndex := range indexArray {
+		if storageTable := storageRemote[storageIndex.RemoteTableName]; storageTable != nil {
+			storageIndexUnit := storageIndex
+			storageTable.IndexMap[storageIndex.IndexName] = &storageIndexUnit
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) upgradeV1(transaction *Transaction, storageRemote map[string]*SchemeStorageTable) (err error) {
+	var (
+		requestArray []interface{}
+		updatedAt    = time.Now().Unix()
+	)
+
+	for _, storageTable := range []*Table{storage.storageTableTable, storage.storageIndexTable} {
+		err = transaction.Execute(NewBuilderCreate(storageTable).IfNotExists(true))
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Execute(NewBuilderUpdate(storage.storageStableTable).SetValue("scheme_header", SchemeHeaderV2).WhereCondition(NewConditionEqual("scheme_header", SchemeHeaderV1)))
+	if err != nil {
+		return
+	}
+
+	for _, storageTable := range storageRemote {
+		storageTable.SchemeHeader = SchemeHeaderV2
+
+		for _, fieldUnit := range storageTable.FieldMap {
+			fieldUnit.SchemeHeader = SchemeHeaderV2
+		}
+
+		requestArray = append(requestArray, schemeHelperStorageTableRecord(storageTable, 0, updatedAt))
+	}
+
+	if len(requestArray) > 0 {
+		err = transaction.ExecuteReplaceValue(storage.storageTableTable, requestArray...)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageDatabase) Import(transaction *Transaction) (storageRemote map[string]*SchemeStorageTable, err error) {
+	var (
+		responseInterface  interface{}
+		responseArray      []SchemeStorageHeader
+		ok                 bool
+		storageHeaderArray []string
+	)
+
+	err = transaction.Execute(NewBuilderCreate(storage.storageStableTable).IfNotExists(true))
+	if err != nil {
+		return
+	}
+
+	responseInterface, err = transaction.Query(NewBuilderSelect(storage.storageHeaderTable).Distinct(true))
+	if err != nil {
+		return
+	}
+
+	responseArray, ok = responseInterface.([]SchemeStorageHeader)
+	if !ok {
+		return nil, ErrorSchemeHasUnsupportedStruct
+	}
+
+	for _, storageHeader := range responseArray {
+		storageHeaderArray = append(storageHeaderArray, storageHeader.SchemeHeader)
+	}
+
+	if len(storageHeaderArray) == 0 {
+		return map[string]*SchemeStorageTable{}, storage.reset(transaction)
+	}
+
+	sort.Strings(storageHeaderArray)
+
+	for _, storageHeader := range storageHeaderArray {
+		switch storageHeader {
+		case SchemeHeaderV1:
+			storageRemote, err = storage.importV1(transaction)
+			if err == nil {
+				err = storage.upgradeV1(transaction, storageRemote)
+			}
+		case SchemeHeaderV2:
+		default:
+			err = fmt.Errorf("%w: %s", ErrorSchemeHasUnsupportedHeader, storageHeader)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	storageRemote, err = storage.importV2(transaction)
+	if err != nil {
+		return
+	}
+
+	storage.storagePrepared = true
+	return
+}
+
+func (storage *schemeStorageDatabase) helperDeleteBuilderArray(tableName string) (builderArray []Builder) {
+	for _, storageTable := range []*Table{storage.storageStableTable, storage.storageTableTable, storage.storageIndexTable} {
+		builderArray = append(builderArray, NewBuilderDelete(storageTable).WhereCondition(NewConditionEqual("remote_table_name", tableName)))
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) helperExportBuilderArray(storageTable *SchemeStorageTable) (builderArray []Builder) {
+	var (
+		requestArray      []interface{}
+		requestIndexArray []interface{}
+	)
+
+	if !storage.storagePrepared {
+		for _, storageUnit := range []*Table{storage.storageStableTable, storage.storageTableTable, storage.storageIndexTable} {
+			builderArray = append(builderArray, NewBuilderCreate(storageUnit).IfNotExists(true))
+		}
+	}
+
+	builderArray = append(builderArray, storage.helperDeleteBuilderArray(storageTable.RemoteTableName)...)
+
+	for _, fieldUnit := range storageTable.FieldMap {
+		requestArray = append(requestArray, SchemeStorageStable{
+			SchemeHeader:         fieldUnit.SchemeHeader,
+			SchemeVersion:        fieldUnit.SchemeVersion,
+			LocalTableName:       fieldUnit.LocalTableName,
+			RemoteTableName:      fieldUnit.RemoteTableName,
+			LocalFieldName:       fieldUnit.LocalFieldName,
+			RemoteFieldName:      fieldUnit.RemoteFieldName,
+			LocalFieldType:       fieldUnit.LocalFieldType,
+			RemoteFieldType:      fieldUnit.RemoteFieldType,
+			FieldIndex:           fieldUnit.FieldIndex,
+			FieldIsPrimaryKey:    fieldUnit.FieldIsPrimaryKey,
+			FieldIsAutoIncrement: fieldUnit.FieldIsAutoIncrement,
+			FieldIsNotNull:       fieldUnit.FieldIsNotNull,
+			FieldInUniqueGroup:   fieldUnit.FieldInUniqueGroup,
+			FieldValueDefault:    fieldUnit.FieldValueDefault,
+			FieldValueCheck:      fieldUnit.FieldValueCheck,
+		})
+	}
+
+	for _, indexUnit := range storageTable.IndexMap {
+		requestIndexArray = append(requestIndexArray, *indexUnit)
+	}
+
+	for _, requestUnit := range []struct {
+		table        *Table
+		requestArray []interface{}
+	}{
+		{storage.storageStableTable, requestArray},
+		{storage.storageTableTable, []interface{}{schemeHelperStorageTableRecord(storageTable, storageTable.MigrationVersion, storageTable.UpdatedAt)}},
+		{storage.storageIndexTable, requestIndexArray},
+	} {
+		for requestOffset := int64(0); requestOffset < int64(len(requestUnit.requestArray)); requestOffset += TransactionReplaceBlock {
+			requestOffsetNext := requestOffset + TransactionReplaceBlock
+			if int64(len(requestUnit.requestArray)) <= requestOffsetNext {
+				requestOffsetNext = int64(len(requestUnit.requestArray))
+			}
+
+			builderArray = append(builderArray, NewBuilderReplace(requestUnit.table).Value(requestUnit.requestArray[requestOffset:requestOffsetNext]...))
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) Export(transaction *Transaction, storageTable *SchemeStorageTable) (err error) {
+	err = storage.prepare(transaction)
+	if err != nil {
+		return
+	}
+
+	for _, builderUnit := range storage.helperExportBuilderArray(storageTable) {
+		err = transaction.Execute(builderUnit)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) Delete(transaction *Transaction, tableName string) (err error) {
+	for _, builderUnit := range storage.helperDeleteBuilderArray(tableName) {
+		err = transaction.Execute(builderUnit)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (storage *schemeStorageDatabase) Commit() error {
+	return nil
+}
+
+func (storage *schemeStorageDatabase) Rollback() error {
+	storage.storagePrepared = false
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperStorageTableRecord(storageTable *SchemeStorageTable, migrationVersion int64, updatedAt int64) SchemeStorageTableStable {
+	return SchemeStorageTableStable{
+		SchemeHeader:     SchemeHeaderStable,
+		SchemeVersion:    storageTable.SchemeVersion,
+		LocalTableName:   storageTable.LocalTableName,
+		RemoteTableName:  storageTable.RemoteTableName,
+		TableHash:        storageTable.GetHash(),
+		TableOption:      storageTable.TableOption,
+		MigrationVersion: migrationVersion,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewSchemeStorageDatabase(storageName string) SchemeStorage {
+	var (
+		storageHeaderTable *Table
+		storageV1Table     *Table
+		storageStableTable *Table
+		storageTableTable  *Table
+		storageIndexTable  *Table
+		err                error
+	)
+
+	storageHeaderTable, err = NewTable(storageName, SchemeStorageHeader{})
+	if err != nil {
+		return nil
+	}
+
+	storageV1Table, err = NewTable(storageName, SchemeStorageV1{})
+	if err != nil {
+		return nil
+	}
+
+	storageStableTable, err = NewTable(storageName, SchemeStorageStable{})
+	if err != nil {
+		return nil
+	}
+
+	storageTableTable, err = NewTable(fmt.Sprintf("%s_table", storageName), SchemeStorageTableStable{})
+	if err != nil {
+		return nil
+	}
+
+	storageIndexTable, err = NewTable(fmt.Sprintf("%s_index", storageName), SchemeStorageIndexStable{})
+	if err != nil {
+		return nil
+	}
+
+	return &schemeStorageDatabase{
+		storageName:        storageName,
+		storageHeaderTable: storageHeaderTable,
+		storageV1Table:     storageV1Table,
+		storageStableTable: storageStableTable,
+		storageTableTable:  storageTableTable,
+		storageIndexTable:  storageIndexTable,
+		storagePrepared:    false,
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,167 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+func testStorageSeed(t *testing.T, databasePath string, tableName string, valueStruct interface{}, valueArray ...interface{}) {
+	t.Helper()
+
+	database, err := NewDatabase(NewTransportSimple("sqlite", databasePath), NewSchemeMemory("seed", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer database.Close()
+
+	table, err := NewTable(tableName, valueStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderCreate(table).IfNotExists(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(valueArray) > 0 {
+		err = database.Execute(NewBuilderInsert(table).Value(valueArray...))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testStorageQuery(t *testing.T, database *Database, tableName string, valueStruct interface{}) interface{} {
+	t.Helper()
+
+	table, err := NewTable(tableName, valueStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response
+}
+
+func testStorageHasTable(t *testing.T, database *Database, tableName string) bool {
+	t.Helper()
+
+	for _, storageTable := range testStorageQuery(t, database, "scheme_table", SchemeStorageTableStable{}).([]SchemeStorageTableStable) {
+		if storageTable.RemoteTableName == tableName {
+			return true
+		}
+	}
+
+	return false
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeStorageDatabaseUpgradeV1(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "upgrade.db")
+
+	tableV1, err := NewTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldMapV1, err := SchemeHelperTableToFieldMap(SchemeHeaderV1, 1, tableV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageV1Array := []interface{}{}
+	for _, fieldUnit := range fieldMapV1.FieldMap {
+		storageV1Array = append(storageV1Array, SchemeStorageV1(*fieldUnit))
+	}
+
+	testStorageSeed(t, databasePath, "users", testSchemeUserV1{})
+	testStorageSeed(t, databasePath, "scheme", SchemeStorageV1{}, storageV1Array...)
+
+	database, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	_, err = database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, storageField := range testStorageQuery(t, database, "scheme", SchemeStorageStable{}).([]SchemeStorageStable) {
+		if storageField.SchemeHeader != SchemeHeaderV2 {
+			t.Errorf("field %s has header %s after the upgrade, want %s", storageField.RemoteFieldName, storageField.SchemeHeader, SchemeHeaderV2)
+		}
+	}
+
+	if !testStorageHasTable(t, database, "users") {
+		t.Error("table records miss users after the upgrade")
+	}
+}
+
+func TestSchemeStorageDatabaseUnsupportedHeader(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "header.db")
+
+	testStorageSeed(t, databasePath, "scheme", SchemeStorageStable{}, SchemeStorageStable{
+		SchemeHeader:    "V9",
+		SchemeVersion:   1,
+		RemoteTableName: "users",
+		RemoteFieldName: "id",
+	})
+
+	_, err := NewDatabase(NewTransportSimple("sqlite", databasePath), NewSchemeDatabase("scheme", 1))
+	if !errors.Is(err, ErrorSchemeHasUnsupportedHeader) {
+		t.Fatalf("opening a newer storage returned %v, want an unsupported header error", err)
+	}
+
+	database, err := NewDatabase(NewTransportSimple("sqlite", databasePath), NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer database.Close()
+
+	if storageArray := testStorageQuery(t, database, "scheme", SchemeStorageStable{}).([]SchemeStorageStable); len(storageArray) != 1 {
+		t.Errorf("storage holds %v after the rejected import, want the newer record kept", storageArray)
+	}
+}
+
+func TestSchemeStorageDatabaseReset(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "reset.db")
+
+	testStorageSeed(t, databasePath, "scheme", SchemeStorageStable{})
+	testStorageSeed(t, databasePath, "scheme_table", SchemeStorageTableStable{}, SchemeStorageTableStable{
+		SchemeHeader:    SchemeHeaderV2,
+		RemoteTableName: "stale",
+		TableHash:       "stale",
+	})
+	testStorageSeed(t, databasePath, "scheme_index", SchemeStorageIndexStable{}, SchemeStorageIndexStable{
+		SchemeHeader:    SchemeHeaderV2,
+		RemoteTableName: "stale",
+		IndexName:       "stale",
+		IndexKind:       SchemeIndexKindIndex,
+		IndexColumn:     "id",
+	})
+
+	database, _ := testSchemeOpen(t, databasePath, 1, nil)
+
+	if testStorageHasTable(t, database, "stale") {
+		t.Error("table records keep the stale table after the reset")
+	}
+
+	for _, storageIndex := range testStorageQuery(t, database, "scheme_index", SchemeStorageIndexStable{}).([]SchemeStorageIndexStable) {
+		if storageIndex.RemoteTableName == "stale" {
+			t.Error("index records keep the stale index after the reset")
+		}
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,268 @@
+package sqlctrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//--------------------------------------------------------------------------------//
+
+type schemeStorageDocumentTable struct {
+	schemeSnapshotTable
+
+	TableHash        string `json:",omitempty"`
+	MigrationVersion int64  `json:",omitempty"`
+	UpdatedAt        int64  `json:",omitempty"`
+}
+
+type schemeStorageDocument struct {
+	SchemeHeader string
+	TableArray   []schemeStorageDocumentTable
+}
+
+type schemeStorageFile struct {
+	storageMemory *schemeStorageMemory
+
+	filePath string
+	fileYaml bool
+	fileData []byte
+	readOnly bool
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageFile) GetName() string {
+	return storage.storageMemory.GetName()
+}
+
+func (storage *schemeStorageFile) GetTableNameArray() []string {
+	return storage.storageMemory.GetTableNameArray()
+}
+
+func (storage *schemeStorageFile) IsReadOnly() bool {
+	return storage.readOnly
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageFile) Import(transaction *Transaction) (map[string]*SchemeStorageTable, error) {
+	if storage.storageMemory.storagePending == nil {
+		storageData := storage.fileData
+
+		if !storage.readOnly {
+			var err error
+
+			storageData, err = os.ReadFile(storage.filePath)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+
+		storageMap, err := SchemeHelperStorageDecode(storageData)
+		if err != nil {
+			return nil, err
+		}
+
+		storage.storageMemory.storageMap = storageMap
+	}
+
+	return storage.storageMemory.Import(transaction)
+}
+
+func (storage *schemeStorageFile) Export(transaction *Transaction, storageTable *SchemeStorageTable) error {
+	if storage.readOnly {
+		return ErrorSchemeStorageIsReadOnly
+	}
+
+	return storage.storageMemory.Export(transaction, storageTable)
+}
+
+func (storage *schemeStorageFile) Delete(transaction *Transaction, tableName string) error {
+	if storage.readOnly {
+		return ErrorSchemeStorageIsReadOnly
+	}
+
+	return storage.storageMemory.Delete(transaction, tableName)
+}
+
+func (storage *schemeStorageFile) Commit() error {
+	if storage.readOnly || storage.storageMemory.storagePending == nil {
+		return storage.storageMemory.Commit()
+	}
+
+	storageData, err := SchemeHelperStorageEncode(storage.storageMemory.storagePending, storage.fileYaml)
+	if err != nil {
+		storage.storageMemory.Rollback()
+		return err
+	}
+
+	fileTemp := fmt.Sprintf("%s.tmp", storage.filePath)
+
+	err = os.WriteFile(fileTemp, storageData, 0644)
+	if err == nil {
+		err = os.Rename(fileTemp, storage.filePath)
+	}
+
+	if err != nil {
+		os.Remove(fileTemp)
+		storage.storageMemory.Rollback()
+		return err
+	}
+
+	return storage.storageMemory.Commit()
+}
+
+func (storage *schemeStorageFile) Rollback() error {
+	return storage.storageMemory.Rollback()
+}
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperStorageDecode(storageData []byte) (map[string]*SchemeStorageTable, error) {
+	var (
+		storageYaml     interface{}
+		storageDocument schemeStorageDocument
+		storageMap      = map[string]*SchemeStorageTable{}
+	)
+
+	err := yaml.Unmarshal(storageData, &storageYaml)
+	if err != nil {
+		return nil, err
+	}
+
+	if storageYaml == nil {
+		return storageMap, nil
+	}
+
+	storageJson, err := json.Marshal(storageYaml)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(storageJson, &storageDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	if storageDocument.SchemeHeader != SchemeHeaderStable {
+		return nil, fmt.Errorf("%w: %s", ErrorSchemeHasUnsupportedHeader, storageDocument.SchemeHeader)
+	}
+
+	for _, documentTable := range storageDocument.TableArray {
+		storageTable := schemeHelperSnapshotTableToFieldMap(documentTable.schemeSnapshotTable)
+		storageTable.MigrationVersion = documentTable.MigrationVersion
+		storageTable.UpdatedAt = documentTable.UpdatedAt
+
+		if len(documentTable.TableHash) > 0 && documentTable.TableHash != storageTable.GetHash() {
+			return nil, fmt.Errorf("%w: %s", ErrorSchemeStorageIsTampered, storageTable.RemoteTableName)
+		}
+
+		storageMap[storageTable.RemoteTableName] = storageTable
+	}
+
+	return storageMap, nil
+}
+
+func SchemeHelperStorageEncode(storageMap map[string]*SchemeStorageTable, storageYaml bool) ([]byte, error) {
+	var (
+		storageNode     yaml.Node
+		storageBuffer   bytes.Buffer
+		storageDocument = schemeStorageDocument{
+			SchemeHeader: SchemeHeaderStable,
+			TableArray:   []schemeStorageDocumentTable{},
+		}
+	)
+
+	for _, storageTable := range storageMap {
+		storageDocument.TableArray = append(storageDocument.TableArray, schemeStorageDocumentTable{
+			schemeSnapshotTable: schemeHelperFieldMapToSnapshotTable(storageTable),
+
+			TableHash:        storageTable.GetHash(),
+			MigrationVersion: storageTable.MigrationVersion,
+			UpdatedAt:        storageTable.UpdatedAt,
+		})
+	}
+
+	sort.Slice(storageDocument.TableArray, func(i, j int) bool {
+		return storageDocument.TableArray[i].RemoteTableName < storageDocument.TableArray[j].RemoteTableName
+	})
+
+	storageJson, err := json.MarshalIndent(storageDocument, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if !storageYaml {
+		return append(storageJson, '\n'), nil
+	}
+
+	err = yaml.Unmarshal(storageJson, &storageNode)
+	if err != nil {
+		return nil, err
+	}
+
+	schemeHelperYamlBlockStyle(&storageNode)
+
+	storageEncoder := yaml.NewEncoder(&storageBuffer)
+	storageEncoder.SetIndent(2)
+
+	err = storageEncoder.Encode(&storageNode)
+	if err == nil {
+		err = storageEncoder.Close()
+	}
+
+	return storageBuffer.Bytes(), err
+}
+
+func schemeHelperYamlBlockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, nodeChild := range node.Content {
+		schemeHelperYamlBlockStyle(nodeChild)
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewSchemeStorageFile(storageName string, filePath string) SchemeStorage {
+	fileExt := strings.ToLower(filepath.Ext(filePath))
+
+	return &schemeStorageFile{
+		storageMemory: NewSchemeStorageMemory(storageName).(*schemeStorageMemory),
+
+		filePath: filePath,
+		fileYaml: fileExt == ".yaml" || fileExt == ".yml",
+		fileData: nil,
+		readOnly: false,
+	}
+}
+
+func NewSchemeStorageEmbedded(storageName string, storageData []byte) SchemeStorage {
+	return &schemeStorageFile{
+		storageMemory: NewSchemeStorageMemory(storageName).(*schemeStorageMemory),
+
+		filePath: "",
+		fileYaml: false,
+		fileData: storageData,
+		readOnly: true,
+	}
+}
+
+func NewSchemeFile(storageName string, filePath string, storageVersion int64) Scheme {
+	return NewScheme(NewSchemeStorageFile(storageName, filePath), storageVersion)
+}
+
+func NewSchemeEmbedded(storageName string, storageData []byte, storageVersion int64) Scheme {
+	return NewScheme(NewSchemeStorageEmbedded(storageName, storageData), storageVersion)
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,132 @@
+package sqlctrl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+func testStorageFileOpen(t *testing.T, databasePath string, scheme Scheme) *Database {
+	t.Helper()
+
+	database, err := NewDatabase(NewTransportSimple("sqlite", databasePath), scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	return database
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeStorageFile(t *testing.T) {
+	var (
+		databasePath = filepath.Join(t.TempDir(), "file.db")
+		storagePath  = filepath.Join(t.TempDir(), "scheme.yaml")
+	)
+
+	databaseV1 := testStorageFileOpen(t, databasePath, NewSchemeFile("scheme", storagePath, 1))
+
+	_, err := databaseV1.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	databaseV2 := testStorageFileOpen(t, databasePath, NewSchemeFile("scheme", storagePath, 2))
+
+	_, err = databaseV2.RegisterTable("users", testSchemeUserV2{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageData, err := os.ReadFile(storagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(storageData), "mail") {
+		t.Errorf("storage file misses the migrated column:\n%s", storageData)
+	}
+
+	tableV2, _ := NewTable("users", testSchemeUserV2{})
+
+	verify, err := databaseV2.Verify(tableV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verify.HasDrift() {
+		t.Errorf("migrated table drifts from the file storage:\n%s", verify)
+	}
+}
+
+func TestSchemeStorageEmbedded(t *testing.T) {
+	var (
+		databasePath = filepath.Join(t.TempDir(), "embedded.db")
+		storagePath  = filepath.Join(t.TempDir(), "scheme.json")
+	)
+
+	databaseFile := testStorageFileOpen(t, databasePath, NewSchemeFile("scheme", storagePath, 1))
+
+	_, err := databaseFile.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageData, err := os.ReadFile(storagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database := testStorageFileOpen(t, databasePath, NewSchemeEmbedded("scheme", storageData, 1))
+
+	_, err = database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.RegisterTable("users", testSchemeUserV2{})
+	if !errors.Is(err, ErrorSchemeStorageIsReadOnly) {
+		t.Errorf("registering a changed table returned %v, want a read-only storage error", err)
+	}
+}
+
+func TestSchemeStorageEmbeddedWithoutHistory(t *testing.T) {
+	database := testStorageFileOpen(t, filepath.Join(t.TempDir(), "history.db"), NewSchemeEmbedded("scheme", nil, 1))
+
+	_, err := database.RegisterTable("users", testSchemeUserV1{})
+	if !errors.Is(err, ErrorSchemeStorageIsReadOnly) {
+		t.Errorf("registering a table missing from the snapshot returned %v, want a read-only storage error", err)
+	}
+}
+
+func TestSchemeStorageMemory(t *testing.T) {
+	database := testStorageFileOpen(t, filepath.Join(t.TempDir(), "memory.db"), NewSchemeMemory("scheme", 1))
+
+	table, err := database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testSchemeUserV1{Id: 1, Name: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageTable, _ := NewTable("scheme", SchemeStorageStable{})
+	if _, err = database.Query(NewBuilderSelect(storageTable)); err == nil {
+		t.Error("memory storage wrote its records to the database")
+	}
+}
+
+//--------------------------------------------------------------------------------//
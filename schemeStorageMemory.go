@@ -0,0 +1,105 @@
+package sqlctrl
+
+//--------------------------------------------------------------------------------//
+
+type schemeStorageMemory struct {
+	storageName    string
+	storageMap     map[string]*SchemeStorageTable
+	storagePending map[string]*SchemeStorageTable
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageMemory) GetName() string {
+	return storage.storageName
+}
+
+func (storage *schemeStorageMemory) GetTableNameArray() []string {
+	return []string{}
+}
+
+func (storage *schemeStorageMemory) IsReadOnly() bool {
+	return false
+}
+
+//--------------------------------------------------------------------------------//
+
+func (storage *schemeStorageMemory) pending() map[string]*SchemeStorageTable {
+	if storage.storagePending == nil {
+		storage.storagePending = schemeHelperStorageMapCopy(storage.storageMap)
+	}
+
+	return storage.storagePending
+}
+
+func (storage *schemeStorageMemory) Import(transaction *Transaction) (map[string]*SchemeStorageTable, error) {
+	if storage.storagePending != nil {
+		return schemeHelperStorageMapCopy(storage.storagePending), nil
+	}
+
+	return schemeHelperStorageMapCopy(storage.storageMap), nil
+}
+
+func (storage *schemeStorageMemory) Export(transaction *Transaction, storageTable *SchemeStorageTable) error {
+	if storageTable == nil {
+		return ErrorTableIsNil
+	}
+
+	storage.pending()[storageTable.RemoteTableName] = schemeHelperStorageTableCopy(storageTable)
+	return nil
+}
+
+func (storage *schemeStorageMemory) Delete(transaction *Transaction, tableName string) error {
+	delete(storage.pending(), tableName)
+	return nil
+}
+
+func (storage *schemeStorageMemory) Commit() error {
+	if storage.storagePending != nil {
+		storage.storageMap = storage.storagePending
+		storage.storagePending = nil
+	}
+
+	return nil
+}
+
+func (storage *schemeStorageMemory) Rollback() error {
+	storage.storagePending = nil
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperStorageTableCopy(storageTable *SchemeStorageTable) *SchemeStorageTable {
+	storageCopy := schemeHelperSnapshotTableToFieldMap(schemeHelperFieldMapToSnapshotTable(storageTable))
+	storageCopy.MigrationVersion = storageTable.MigrationVersion
+	storageCopy.UpdatedAt = storageTable.UpdatedAt
+
+	return storageCopy
+}
+
+func schemeHelperStorageMapCopy(storageMap map[string]*SchemeStorageTable) map[string]*SchemeStorageTable {
+	storageCopy := make(map[string]*SchemeStorageTable, len(storageMap))
+
+	for tableName, storageTable := range storageMap {
+		storageCopy[tableName] = schemeHelperStorageTableCopy(storageTable)
+	}
+
+	return storageCopy
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewSchemeStorageMemory(storageName string) SchemeStorage {
+	return &schemeStorageMemory{
+		storageName:    storageName,
+		storageMap:     map[string]*SchemeStorageTable{},
+		storagePending: nil,
+	}
+}
+
+func NewSchemeMemory(storageName string, storageVersion int64) Scheme {
+	return NewScheme(NewSchemeStorageMemory(storageName), storageVersion)
+}
+
+//--------------------------------------------------------------------------------//
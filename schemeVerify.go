@@ -0,0 +1,252 @@
+package sqlctrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	SchemeVerifySourceLive  = "live"
+	SchemeVerifySourceLocal = "local"
+)
+
+const (
+	SchemeVerifyKindTableMissing   = "table_missing"
+	SchemeVerifyKindTableUnmanaged = "table_unmanaged"
+	SchemeVerifyKindTableUnstored  = "table_unstored"
+	SchemeVerifyKindColumnMissing  = "column_missing"
+	SchemeVerifyKindColumnExtra    = "column_extra"
+	SchemeVerifyKindColumnRenamed  = "column_renamed"
+	SchemeVerifyKindType           = "type"
+	SchemeVerifyKindConstraint     = "constraint"
+)
+
+var (
+	schemeVerifyTypeWidthRegexp = regexp.MustCompile(`^([A-Z ]*INT[A-Z]*|[A-Z ]*TEXT)\s*\([0-9]+\)(.*)$`)
+	schemeVerifyCheckRegexp     = regexp.MustCompile("[`\"'()\\s]")
+)
+
+type SchemeVerifyDrift struct {
+	Source     string `json:"source"`
+	Kind       string `json:"kind"`
+	Column     string `json:"column,omitempty"`
+	Constraint string `json:"constraint,omitempty"`
+	Expected   string `json:"expected,omitempty"`
+	Actual     string `json:"actual,omitempty"`
+}
+
+type SchemeVerifyTable struct {
+	Table      string              `json:"table"`
+	DriftArray []SchemeVerifyDrift `json:"drift,omitempty"`
+}
+
+type SchemeVerify struct {
+	TableArray []*SchemeVerifyTable `json:"tables"`
+}
+
+//--------------------------------------------------------------------------------//
+
+func (verify *SchemeVerify) HasDrift() bool {
+	for _, verifyTable := range verify.TableArray {
+		if len(verifyTable.DriftArray) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (verify *SchemeVerify) Json() ([]byte, error) {
+	return json.MarshalIndent(verify, "", "  ")
+}
+
+func (verify *SchemeVerify) String() string {
+	var verifyText strings.Builder
+
+	for _, verifyTable := range verify.TableArray {
+		if len(verifyTable.DriftArray) == 0 {
+			fmt.Fprintf(&verifyText, "table %s: ok\n", verifyTable.Table)
+			continue
+		}
+
+		fmt.Fprintf(&verifyText, "table %s: drift\n", verifyTable.Table)
+
+		for _, verifyDrift := range verifyTable.DriftArray {
+			verifyLine := []string{fmt.Sprintf("  %s %s", verifyDrift.Source, verifyDrift.Kind)}
+
+			if len(verifyDrift.Column) > 0 {
+				verifyLine = append(verifyLine, verifyDrift.Column)
+			}
+
+			if len(verifyDrift.Constraint) > 0 {
+				verifyLine = append(verifyLine, verifyDrift.Constraint)
+			}
+
+			if len(verifyDrift.Expected) > 0 {
+				verifyLine = append(verifyLine, fmt.Sprintf("expected %s", verifyDrift.Expected))
+			}
+
+			if len(verifyDrift.Actual) > 0 {
+				verifyLine = append(verifyLine, fmt.Sprintf("actual %s", verifyDrift.Actual))
+			}
+
+			fmt.Fprintf(&verifyText, "%s\n", strings.Join(verifyLine, " "))
+		}
+	}
+
+	return verifyText.String()
+}
+
+//--------------------------------------------------------------------------------//
+
+func SchemeHelperVerifyLive(fieldMapExpected *SchemeStorageTable, fieldMapLive *SchemeStorageTable) (driftArray []SchemeVerifyDrift) {
+	if fieldMapExpected == nil {
+		return
+	}
+
+	if fieldMapLive == nil {
+		return []SchemeVerifyDrift{{Source: SchemeVerifySourceLive, Kind: SchemeVerifyKindTableMissing}}
+	}
+
+	for _, fieldExpectedUnit := range schemeHelperPlanFieldArray(fieldMapExpected) {
+		fieldLiveUnit, ok := fieldMapLive.FieldMap[fieldExpectedUnit.RemoteFieldName]
+		if !ok {
+			driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLive, Kind: SchemeVerifyKindColumnMissing, Column: fieldExpectedUnit.RemoteFieldName, Expected: fieldExpectedUnit.RemoteFieldType})
+			continue
+		}
+
+		typeExpected, typeLive := schemeHelperVerifyType(fieldExpectedUnit), schemeHelperVerifyType(fieldLiveUnit)
+		if typeExpected != typeLive {
+			driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLive, Kind: SchemeVerifyKindType, Column: fieldExpectedUnit.RemoteFieldName, Expected: typeExpected, Actual: typeLive})
+		}
+
+		for _, constraintUnit := range []struct {
+			name             string
+			expected, actual string
+		}{
+			{"PRIMARY_KEY", fmt.Sprint(fieldExpectedUnit.FieldIsPrimaryKey), fmt.Sprint(fieldLiveUnit.FieldIsPrimaryKey)},
+			{"AUTO_INCREMENT", fmt.Sprint(fieldExpectedUnit.FieldIsAutoIncrement), fmt.Sprint(fieldLiveUnit.FieldIsAutoIncrement)},
+			{"NOT_NULL", fmt.Sprint(fieldExpectedUnit.FieldIsNotNull || fieldExpectedUnit.FieldIsPrimaryKey), fmt.Sprint(fieldLiveUnit.FieldIsNotNull || fieldLiveUnit.FieldIsPrimaryKey)},
+			{"UNIQUE_GROUP", schemeHelperVerifyUnique(fieldMapExpected, fieldExpectedUnit), schemeHelperVerifyUnique(fieldMapLive, fieldLiveUnit)},
+			{"DEFAULT", schemeHelperVerifyDefault(fieldExpectedUnit.FieldValueDefault), schemeHelperVerifyDefault(fieldLiveUnit.FieldValueDefault)},
+			{"CHECK", schemeHelperVerifyCheck(fieldExpectedUnit.FieldValueCheck), schemeHelperVerifyCheck(fieldLiveUnit.FieldValueCheck)},
+		} {
+			if constraintUnit.expected != constraintUnit.actual {
+				driftArray = append(driftArray, SchemeVerifyDrift{
+					Source:     SchemeVerifySourceLive,
+					Kind:       SchemeVerifyKindConstraint,
+					Column:     fieldExpectedUnit.RemoteFieldName,
+					Constraint: constraintUnit.name,
+					Expected:   constraintUnit.expected,
+					Actual:     constraintUnit.actual,
+				})
+			}
+		}
+	}
+
+	for _, fieldLiveUnit := range schemeHelperPlanFieldArray(fieldMapLive) {
+		if _, ok := fieldMapExpected.FieldMap[fieldLiveUnit.RemoteFieldName]; !ok {
+			driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLive, Kind: SchemeVerifyKindColumnExtra, Column: fieldLiveUnit.RemoteFieldName, Actual: fieldLiveUnit.RemoteFieldType})
+		}
+	}
+
+	return
+}
+
+func SchemeHelperVerifyLocal(sqlDialect string, table *Table, fieldMapRemote *SchemeStorageTable, fieldMapLocal *SchemeStorageTable) (driftArray []SchemeVerifyDrift) {
+	if fieldMapLocal == nil {
+		return
+	}
+
+	if fieldMapRemote == nil {
+		return []SchemeVerifyDrift{{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindTableUnstored}}
+	}
+
+	planTable := SchemeHelperPlanTable(sqlDialect, table, fieldMapRemote, fieldMapLocal)
+
+	for _, planColumn := range planTable.AddedArray {
+		driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindColumnExtra, Column: planColumn.Name, Actual: planColumn.Type})
+	}
+
+	for _, planColumn := range planTable.RemovedArray {
+		driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindColumnMissing, Column: planColumn.Name, Expected: planColumn.Type})
+	}
+
+	for _, planColumn := range planTable.RenamedArray {
+		driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindColumnRenamed, Column: planColumn.Name, Expected: planColumn.NameFrom, Actual: planColumn.Name})
+	}
+
+	for _, planColumn := range planTable.RetypedArray {
+		driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindType, Column: planColumn.Name, Expected: planColumn.TypeFrom, Actual: planColumn.Type})
+	}
+
+	for _, planConstraint := range planTable.ConstraintArray {
+		driftArray = append(driftArray, SchemeVerifyDrift{Source: SchemeVerifySourceLocal, Kind: SchemeVerifyKindConstraint, Column: planConstraint.Column, Constraint: planConstraint.Constraint, Expected: planConstraint.From, Actual: planConstraint.To})
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func schemeHelperVerifyType(fieldUnit *SchemeStorageStable) string {
+	fieldType := strings.Join(strings.Fields(strings.ToUpper(fieldUnit.RemoteFieldType)), " ")
+
+	if fieldUnit.FieldIsPrimaryKey && fieldUnit.FieldIsAutoIncrement {
+		fieldType = "INTEGER"
+	}
+
+	if typeMatch := schemeVerifyTypeWidthRegexp.FindStringSubmatch(fieldType); typeMatch != nil {
+		fieldType = typeMatch[1] + typeMatch[2]
+	}
+
+	switch {
+	case fieldType == "INTEGER":
+		fieldType = "INT"
+	case strings.HasSuffix(fieldType, "TEXT"), fieldType == "JSON":
+		fieldType = "TEXT"
+	case fieldType == "REAL":
+		fieldType = "DOUBLE"
+	}
+
+	return fieldType
+}
+
+func schemeHelperVerifyDefault(value *string) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	return strings.Trim(strings.TrimSpace(*value), "()'\"")
+}
+
+func schemeHelperVerifyCheck(value *string) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	return strings.ToLower(schemeVerifyCheckRegexp.ReplaceAllString(*value, ""))
+}
+
+func schemeHelperVerifyUnique(fieldMap *SchemeStorageTable, fieldUnit *SchemeStorageStable) string {
+	if fieldUnit.FieldInUniqueGroup == nil {
+		return "NULL"
+	}
+
+	uniqueArray := []string{}
+	for _, fieldOther := range fieldMap.FieldMap {
+		if fieldOther.FieldInUniqueGroup != nil && *fieldOther.FieldInUniqueGroup == *fieldUnit.FieldInUniqueGroup {
+			uniqueArray = append(uniqueArray, fieldOther.RemoteFieldName)
+		}
+	}
+
+	sort.Strings(uniqueArray)
+	return fmt.Sprintf("(%s)", strings.Join(uniqueArray, ", "))
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,44 @@
+package sqlctrl
+
+import (
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+func TestSchemeVerify(t *testing.T) {
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "verify.db"), 1, nil)
+
+	table, err := database.RegisterTable("users", testSchemeUserV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify, err := database.Verify(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verify.HasDrift() {
+		t.Errorf("freshly registered table drifts:\n%s", verify)
+	}
+
+	err = database.Execute(NewBuilderRaw("ALTER TABLE users ADD COLUMN extra INTEGER"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify, err = database.Verify(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !verify.HasDrift() {
+		t.Error("verify missed a column added outside the scheme")
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -1,11 +1,17 @@
 package sqlctrl
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"html"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 //--------------------------------------------------------------------------------//
@@ -24,11 +30,57 @@ type TableField struct {
 	isPrimaryKey    bool
 	isAutoIncrement bool
 	isNotNull       bool
+	isJson          bool
+	isTime          bool
+	isValuer        bool
+
+	isAutoCreateTime bool
+	isAutoUpdateTime bool
+	isSoftDelete     bool
+	isVersion        bool
 
 	inUniqueGroup *string
+	inIndexGroup  *string
+	isIndexDesc   bool
+	indexWhere    *string
 
 	valueDefault *string
 	valueCheck   *string
+
+	referenceTable    *string
+	referenceColumn   *string
+	referenceOnDelete *string
+	referenceOnUpdate *string
+
+	renamedFrom []string
+
+	timeSetRegexp *regexp.Regexp
+}
+
+var tableFieldReferenceRegexp = regexp.MustCompile(`^\s*([^\s()]+)\s*\(\s*([^\s()]+)\s*\)\s*$`)
+
+var (
+	tableScannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	tableValuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+//--------------------------------------------------------------------------------//
+
+type tableFieldNameArray []string
+
+func (nameArray *tableFieldNameArray) String() string {
+	return strings.Join(*nameArray, ",")
+}
+
+func (nameArray *tableFieldNameArray) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			*nameArray = append(*nameArray, name)
+		}
+	}
+
+	return nil
 }
 
 //--------------------------------------------------------------------------------//
@@ -69,6 +121,30 @@ func (field *TableField) IsNotNull() bool {
 	return field.isNotNull
 }
 
+func (field *TableField) IsJson() bool {
+	return field.isJson
+}
+
+func (field *TableField) IsTime() bool {
+	return field.isTime
+}
+
+func (field *TableField) IsAutoCreateTime() bool {
+	return field.isAutoCreateTime
+}
+
+func (field *TableField) IsAutoUpdateTime() bool {
+	return field.isAutoUpdateTime
+}
+
+func (field *TableField) IsSoftDelete() bool {
+	return field.isSoftDelete
+}
+
+func (field *TableField) IsVersion() bool {
+	return field.isVersion
+}
+
 func (field *TableField) InUniqueGroup() *string {
 	return field.inUniqueGroup
 }
@@ -81,12 +157,44 @@ func (field *TableField) ValueCheck() *string {
 	return field.valueCheck
 }
 
+func (field *TableField) InIndexGroup() *string {
+	return field.inIndexGroup
+}
+
+func (field *TableField) IsIndexDesc() bool {
+	return field.isIndexDesc
+}
+
+func (field *TableField) IndexWhere() *string {
+	return field.indexWhere
+}
+
+func (field *TableField) ReferenceTable() *string {
+	return field.referenceTable
+}
+
+func (field *TableField) ReferenceColumn() *string {
+	return field.referenceColumn
+}
+
+func (field *TableField) ReferenceOnDelete() *string {
+	return field.referenceOnDelete
+}
+
+func (field *TableField) ReferenceOnUpdate() *string {
+	return field.referenceOnUpdate
+}
+
+func (field *TableField) RenamedFrom() []string {
+	return field.renamedFrom
+}
+
 //--------------------------------------------------------------------------------//
 
-func (field *TableField) parseReflect() bool {
+func (field *TableField) parseReflect() (bool, error) {
 	goFieldTag, ok := field.goField.Tag.Lookup("sql")
 	if !ok {
-		return false
+		return false, nil
 	}
 
 	goFieldTagSlice := strings.Split(goFieldTag, "|")
@@ -97,26 +205,86 @@ func (field *TableField) parseReflect() bool {
 	fs := flag.NewFlagSet("", flag.ContinueOnError)
 	fs.Usage = func() {}
 
-	fs.StringVar(&field.sqlName, "NAME", field.goName, "name")
+	fs.StringVar(&field.sqlName, "NAME", field.goField.Name, "name")
 	fs.StringVar(&field.sqlType, "TYPE", "", "type")
 	fs.BoolVar(&field.isPrimaryKey, "PRIMARY_KEY", false, "primary_key")
 	fs.BoolVar(&field.isAutoIncrement, "AUTO_INCREMENT", false, "auto_increment")
 	fs.BoolVar(&field.isNotNull, "NOT_NULL", false, "not_null")
+	fs.BoolVar(&field.isJson, "JSON", false, "json")
+	fs.BoolVar(&field.isAutoCreateTime, "AUTO_CREATE_TIME", false, "auto_create_time")
+	fs.BoolVar(&field.isAutoUpdateTime, "AUTO_UPDATE_TIME", false, "auto_update_time")
+	fs.BoolVar(&field.isSoftDelete, "SOFT_DELETE", false, "soft_delete")
+	fs.BoolVar(&field.isVersion, "VERSION", false, "version")
 	var fieldIsUnique bool
 	fs.BoolVar(&fieldIsUnique, "UNIQUE", false, "unique")
 	field.inUniqueGroup = fs.String("UNIQUE_GROUP", "", "unique_group")
+	field.inIndexGroup = fs.String("INDEX", "", "index")
+	fs.BoolVar(&field.isIndexDesc, "INDEX_DESC", false, "index_desc")
+	field.indexWhere = fs.String("INDEX_WHERE", "", "index_where")
 	field.valueDefault = fs.String("DEFAULT", "", "default")
 	field.valueCheck = fs.String("CHECK", "", "check")
+	fieldReferences := fs.String("REFERENCES", "", "references")
+	field.referenceOnDelete = fs.String("ON_DELETE", "", "on_delete")
+	field.referenceOnUpdate = fs.String("ON_UPDATE", "", "on_update")
+	var fieldRenamedFrom tableFieldNameArray
+	fs.Var(&fieldRenamedFrom, "RENAMED_FROM", "renamed_from")
 
 	fs.Parse(goFieldTagSlice)
 
-	if field.sqlType == "" {
-		fieldGoType := field.goType
+	field.renamedFrom = fieldRenamedFrom
+
+	fieldGoType := field.goType
+	if field.goType == reflect.Ptr {
+		fieldGoType = field.goField.Type.Elem().Kind()
+	}
+
+	switch {
+	case field.goField.Type == tableTimeType || (field.goType == reflect.Ptr && field.goField.Type.Elem() == tableTimeType):
+		field.isTime, field.isJson = true, false
+	case helperTypeIsValuer(field.goField.Type):
+		field.isValuer, field.isJson = true, false
+		fieldGoType = helperValuerKind(field.goField.Type)
+	case fieldGoType == reflect.Map, fieldGoType == reflect.Slice, fieldGoType == reflect.Array, fieldGoType == reflect.Struct, fieldGoType == reflect.Interface:
+		field.isJson = true
+	}
 
-		if field.goType == reflect.Ptr {
-			fieldGoType = field.goField.Type.Elem().Kind()
+	if field.isAutoCreateTime || field.isAutoUpdateTime {
+		switch fieldGoType {
+		case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		default:
+			if !field.isTime {
+				return false, fmt.Errorf("%w: %s must be a time.Time or an integer", ErrorTableFieldHasInvalidTime, field.goName)
+			}
+		}
+	}
+
+	if field.isSoftDelete {
+		switch {
+		case field.goType == reflect.Bool:
+		case field.goType == reflect.Ptr && field.isTime:
+		case field.goType == reflect.Ptr && fieldGoType >= reflect.Int && fieldGoType <= reflect.Uint64:
+		default:
+			return false, fmt.Errorf("%w: %s must be a bool or a nullable time.Time or integer", ErrorTableFieldHasInvalidSoftDelete, field.goName)
+		}
+	}
+
+	if field.isVersion {
+		switch field.goType {
+		case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		default:
+			return false, fmt.Errorf("%w: %s must be an integer", ErrorTableFieldHasInvalidVersion, field.goName)
 		}
+	}
+
+	if field.sqlType == "" && field.isJson {
+		field.sqlType = "JSON"
+	}
+
+	if field.sqlType == "" && field.isTime {
+		field.sqlType = "DATETIME(6)"
+	}
 
+	if field.sqlType == "" {
 		switch fieldGoType {
 		case reflect.Bool:
 			field.sqlType = "INTEGER(1)"
@@ -147,6 +315,17 @@ func (field *TableField) parseReflect() bool {
 		}
 	}
 
+	if len(*field.inIndexGroup) == 0 {
+		if field.isIndexDesc || len(*field.indexWhere) > 0 {
+			return false, fmt.Errorf("%w: %s without INDEX", ErrorTableFieldHasInvalidIndex, field.goName)
+		}
+
+		field.inIndexGroup = nil
+	}
+	if len(*field.indexWhere) == 0 {
+		field.indexWhere = nil
+	}
+
 	if len(*field.valueDefault) == 0 {
 		field.valueDefault = nil
 	}
@@ -154,32 +333,228 @@ func (field *TableField) parseReflect() bool {
 		field.valueCheck = nil
 	}
 
-	return true
+	if len(*fieldReferences) > 0 {
+		referenceMatch := tableFieldReferenceRegexp.FindStringSubmatch(*fieldReferences)
+		if referenceMatch == nil {
+			return false, fmt.Errorf("%w: %s.%s", ErrorTableFieldHasInvalidReference, field.goName, *fieldReferences)
+		}
+
+		field.referenceTable = &referenceMatch[1]
+		field.referenceColumn = &referenceMatch[2]
+	}
+
+	for _, referenceAction := range []**string{&field.referenceOnDelete, &field.referenceOnUpdate} {
+		if len(**referenceAction) == 0 {
+			*referenceAction = nil
+			continue
+		}
+
+		action := strings.Join(strings.Fields(strings.ToUpper(strings.ReplaceAll(**referenceAction, "_", " "))), " ")
+
+		switch action {
+		case "CASCADE", "RESTRICT", "SET NULL", "SET DEFAULT", "NO ACTION":
+		default:
+			return false, fmt.Errorf("%w: %s.%s", ErrorTableFieldHasInvalidReference, field.goName, **referenceAction)
+		}
+
+		if field.referenceTable == nil {
+			return false, fmt.Errorf("%w: %s.%s without REFERENCES", ErrorTableFieldHasInvalidReference, field.goName, action)
+		}
+
+		*referenceAction = &action
+	}
+
+	return true, nil
+}
+
+//--------------------------------------------------------------------------------//
+
+func (field *TableField) helperValue(structValue reflect.Value) reflect.Value {
+	return structValue.FieldByIndex(field.goField.Index)
+}
+
+func (field *TableField) helperScan(fieldPtr interface{}) interface{} {
+	switch {
+	case field.isJson:
+		return &tableFieldJson{
+			fieldValue: reflect.ValueOf(fieldPtr).Elem(),
+		}
+	case field.isTime:
+		return &tableFieldTime{
+			fieldValue: reflect.ValueOf(fieldPtr).Elem(),
+		}
+	default:
+		return fieldPtr
+	}
+}
+
+func (field *TableField) helperValueEncode(fieldValue reflect.Value) (interface{}, error) {
+	switch {
+	case field.isJson:
+		return SqlFieldValueToJson(fieldValue)
+	case field.isTime:
+		return SqlFieldValueToTime(fieldValue)
+	case field.isValuer:
+		return SqlFieldValueToValuer(fieldValue)
+	case fieldValue.IsValid():
+		return fieldValue.Interface(), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (field *TableField) helperValueString(fieldValue reflect.Value) (string, error) {
+	switch {
+	case field.isJson:
+		return SqlFieldJsonToString(fieldValue)
+	case field.isTime:
+		return SqlFieldTimeToString(fieldValue)
+	case field.isValuer:
+		return SqlFieldValuerToString(fieldValue)
+	default:
+		return SqlFieldValueToString(field.goType, fieldValue)
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+type tableFieldJson struct {
+	fieldValue reflect.Value
+}
+
+func (fieldJson *tableFieldJson) Scan(value interface{}) error {
+	fieldJson.fieldValue.Set(reflect.Zero(fieldJson.fieldValue.Type()))
+
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(value, fieldJson.fieldValue.Addr().Interface())
+	case string:
+		return json.Unmarshal([]byte(value), fieldJson.fieldValue.Addr().Interface())
+	default:
+		return fmt.Errorf("%w: %T", ErrorTableFieldHasInvalidJson, value)
+	}
 }
 
 //--------------------------------------------------------------------------------//
 
-func registerTableField(table *Table, fiedlIndex int) (*TableField, error) {
+func helperTypeIsValuer(goType reflect.Type) bool {
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+
+	return goType.Kind() == reflect.Struct && (goType.Implements(tableValuerType) || reflect.PtrTo(goType).Implements(tableScannerType))
+}
+
+func helperValuerKind(goType reflect.Type) reflect.Kind {
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+
+	if goType.NumField() == 0 {
+		return reflect.String
+	}
+
+	if goType.Field(0).Type == tableTimeType {
+		return reflect.String
+	}
+
+	return goType.Field(0).Type.Kind()
+}
+
+func helperTagHasOption(goFieldTag string, optionName string) bool {
+	for _, sqlTagOption := range strings.Split(goFieldTag, "|") {
+		if sqlTagName, _, _ := strings.Cut(strings.TrimSpace(sqlTagOption), "="); sqlTagName == optionName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func registerTableStruct(table *Table, structType reflect.Type, structIndex []int, goPrefix string, sqlPrefix string) error {
+	if table == nil {
+		return ErrorTableFieldMustHaveATable
+	}
+
+	for fieldIndex := 0; fieldIndex < structType.NumField(); fieldIndex++ {
+		reflectStructField := structType.Field(fieldIndex)
+		reflectStructField.Index = append(append([]int{}, structIndex...), fieldIndex)
+
+		ok, err := registerTableRelation(table, reflectStructField)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			continue
+		}
+
+		goFieldTag, tagged := reflectStructField.Tag.Lookup("sql")
+		if reflectStructField.Type.Kind() == reflect.Struct && (reflectStructField.Anonymous || (tagged && helperTagHasOption(goFieldTag, "PREFIX"))) && reflectStructField.Type != tableTimeType && !helperTypeIsValuer(reflectStructField.Type) && !helperTagHasOption(goFieldTag, "JSON") {
+			if !reflectStructField.IsExported() {
+				return fmt.Errorf("%w: %s.%s%s is unexported", ErrorTableReferenceIsUnsupported, table.GetGoName(), goPrefix, reflectStructField.Name)
+			}
+
+			fieldGoPrefix := goPrefix
+			if !reflectStructField.Anonymous {
+				fieldGoPrefix = fmt.Sprintf("%s%s.", goPrefix, reflectStructField.Name)
+			}
+
+			fieldSqlPrefix := sqlPrefix
+			for _, sqlTagOption := range strings.Split(goFieldTag, "|") {
+				if sqlTagName, sqlTagValue, _ := strings.Cut(strings.TrimSpace(sqlTagOption), "="); sqlTagName == "PREFIX" {
+					fieldSqlPrefix = sqlPrefix + sqlTagValue
+				}
+			}
+
+			err = registerTableStruct(table, reflectStructField.Type, reflectStructField.Index, fieldGoPrefix, fieldSqlPrefix)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		_, err = registerTableField(table, reflectStructField, goPrefix, sqlPrefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func registerTableField(table *Table, reflectStructField reflect.StructField, goPrefix string, sqlPrefix string) (*TableField, error) {
 	var (
-		reflectStructField reflect.StructField
-		field              *TableField
+		field *TableField
 	)
 
 	if table == nil {
 		return nil, ErrorTableFieldMustHaveATable
 	}
 
-	reflectStructField = table.goType.Field(fiedlIndex)
-
 	field = &TableField{
 		goIndex: len(table.goFieldNameArray),
-		goName:  reflectStructField.Name,
+		goName:  goPrefix + reflectStructField.Name,
 		goField: reflectStructField,
 		goType:  reflectStructField.Type.Kind(),
 	}
 
-	if !field.parseReflect() {
-		return nil, nil
+	ok, err := field.parseReflect()
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	field.sqlName = sqlPrefix + field.sqlName
+
+	if field.isAutoUpdateTime {
+		field.timeSetRegexp = field.helperTimeSetRegexp()
+	}
+
+	if table.goFieldMap[field.goName] != nil || table.sqlFieldMap[field.sqlName] != nil {
+		return nil, fmt.Errorf("%w: %s.%s", ErrorTableFieldHasDuplicateName, table.GetGoName(), field.sqlName)
 	}
 
 	table.goFieldNameArray = append(table.goFieldNameArray, field.goName)
@@ -208,6 +583,26 @@ func registerTableField(table *Table, fiedlIndex int) (*TableField, error) {
 		}
 	}
 
+	if field.inIndexGroup != nil {
+		table.goIndexMap[*field.inIndexGroup] = append(table.goIndexMap[*field.inIndexGroup], field)
+	}
+
+	if field.isSoftDelete {
+		if table.goSoftDelete != nil {
+			return nil, fmt.Errorf("%w: %s has several SOFT_DELETE fields", ErrorTableFieldHasInvalidSoftDelete, table.GetGoName())
+		}
+
+		table.goSoftDelete = field
+	}
+
+	if field.isVersion {
+		if table.goVersion != nil {
+			return nil, fmt.Errorf("%w: %s has several VERSION fields", ErrorTableFieldHasInvalidVersion, table.GetGoName())
+		}
+
+		table.goVersion = field
+	}
+
 	return field, nil
 }
 
@@ -230,6 +625,14 @@ type Table struct {
 	goPrimaryKeyArray []*TableField
 	goAutoIncrement   *TableField
 	goUniqueMap       map[string][]*TableField
+	goIndexMap        map[string][]*TableField
+	goSoftDelete      *TableField
+	goVersion         *TableField
+
+	goRelationNameArray []string
+	goRelationMap       map[string]*TableRelation
+
+	clock func() time.Time
 }
 
 //--------------------------------------------------------------------------------//
@@ -291,6 +694,65 @@ func (table *Table) GetUniqueArray(uniqueName string) (uniqueArray []*TableField
 	return uniqueArray
 }
 
+func (table *Table) GetIndexNameArray() (indexNameArray []string) {
+	for indexName := range table.goIndexMap {
+		indexNameArray = append(indexNameArray, indexName)
+	}
+
+	sort.Strings(indexNameArray)
+	return indexNameArray
+}
+
+func (table *Table) GetIndexArray(indexName string) (indexArray []*TableField) {
+	indexArray = table.goIndexMap[indexName]
+	return indexArray
+}
+
+func (table *Table) GetIndexWhere(indexName string) *string {
+	for _, tableField := range table.goIndexMap[indexName] {
+		if tableField.indexWhere != nil {
+			return tableField.indexWhere
+		}
+	}
+
+	return nil
+}
+
+func (table *Table) GetSoftDelete() (softDelete *TableField) {
+	return table.goSoftDelete
+}
+
+func (table *Table) GetVersion() (version *TableField) {
+	return table.goVersion
+}
+
+func (table *Table) GetRelationNameArray() (relationNameArray []string) {
+	return table.goRelationNameArray
+}
+
+func (table *Table) GetRelation(relationGoName string) (relation *TableRelation) {
+	return table.goRelationMap[relationGoName]
+}
+
+func (table *Table) GetReferenceArray() (referenceArray []*TableField) {
+	for _, fieldGoName := range table.goFieldNameArray {
+		if tableField := table.goFieldMap[fieldGoName]; tableField.referenceTable != nil {
+			referenceArray = append(referenceArray, tableField)
+		}
+	}
+
+	return
+}
+
+func (table *Table) GetClock() (clock func() time.Time) {
+	return table.clock
+}
+
+func (table *Table) SetClock(clock func() time.Time) *Table {
+	table.clock = clock
+	return table
+}
+
 //--------------------------------------------------------------------------------//
 
 func (table *Table) GetStruct(tableStruct interface{}) (tableStructPtr interface{}, fieldArrayPtr []interface{}, err error) {
@@ -320,7 +782,7 @@ func (table *Table) GetStruct(tableStruct interface{}) (tableStructPtr interface
 	tableStructPtr = tableReflectValue.Addr().Interface()
 
 	for _, fieldGoName := range table.goFieldNameArray {
-		fieldReflectValue := tableReflectValue.FieldByName(fieldGoName)
+		fieldReflectValue := table.goFieldMap[fieldGoName].helperValue(tableReflectValue)
 		fieldArrayPtr = append(fieldArrayPtr, fieldReflectValue.Addr().Interface())
 	}
 
@@ -352,13 +814,24 @@ func NewTable(tableName string, tableStruct interface{}) (table *Table, err erro
 		goPrimaryKeyArray: []*TableField{},
 		goAutoIncrement:   nil,
 		goUniqueMap:       map[string][]*TableField{},
+		goIndexMap:        map[string][]*TableField{},
+		goSoftDelete:      nil,
+		goVersion:         nil,
+
+		goRelationNameArray: []string{},
+		goRelationMap:       map[string]*TableRelation{},
+
+		clock: nil,
 	}
 
-	for fieldIndex := 0; fieldIndex < tableReflectType.NumField(); fieldIndex++ {
-		_, err = registerTableField(table, fieldIndex)
-		if err != nil {
-			return
-		}
+	err = registerTableStruct(table, tableReflectType, []int{}, "", "")
+	if err != nil {
+		return
+	}
+
+	err = resolveTableRelation(table)
+	if err != nil {
+		return
 	}
 
 	if table.goAutoIncrement != nil {
@@ -395,7 +868,7 @@ func SqlFieldValueToString(goType reflect.Kind, reflectValue reflect.Value) (val
 		if reflectValue.IsNil() {
 			valueString = "NULL"
 		} else {
-			valueString = fmt.Sprintf("'%v'", reflectValue.Elem())
+			valueString = fmt.Sprintf("'%s'", strings.ReplaceAll(fmt.Sprint(reflectValue.Elem()), "'", "''"))
 		}
 	default:
 		err = ErrorTableReferenceIsUnsupported
@@ -404,4 +877,79 @@ func SqlFieldValueToString(goType reflect.Kind, reflectValue reflect.Value) (val
 	return
 }
 
+func SqlFieldValueToJson(reflectValue reflect.Value) (value interface{}, err error) {
+	switch reflectValue.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if reflectValue.IsNil() {
+			return nil, nil
+		}
+	}
+
+	valueByte, err := json.Marshal(reflectValue.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorTableFieldHasInvalidJson, err)
+	}
+
+	return string(valueByte), nil
+}
+
+func SqlFieldJsonToString(reflectValue reflect.Value) (valueString string, err error) {
+	value, err := SqlFieldValueToJson(reflectValue)
+	if err != nil || value == nil {
+		return "NULL", err
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value.(string), "'", "''")), nil
+}
+
+func SqlFieldValueToValuer(reflectValue reflect.Value) (value interface{}, err error) {
+	switch reflectValue.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Ptr, reflect.Interface:
+		if reflectValue.IsNil() {
+			return nil, nil
+		}
+	}
+
+	valuer, ok := reflectValue.Interface().(driver.Valuer)
+	if !ok {
+		if !reflectValue.CanAddr() {
+			reflectValuePtr := reflect.New(reflectValue.Type())
+			reflectValuePtr.Elem().Set(reflectValue)
+			reflectValue = reflectValuePtr.Elem()
+		}
+
+		if valuer, ok = reflectValue.Addr().Interface().(driver.Valuer); !ok {
+			return reflectValue.Interface(), nil
+		}
+	}
+
+	return valuer.Value()
+}
+
+func SqlFieldValuerToString(reflectValue reflect.Value) (valueString string, err error) {
+	value, err := SqlFieldValueToValuer(reflectValue)
+	if err != nil {
+		return
+	}
+
+	switch value := value.(type) {
+	case nil:
+		valueString = "NULL"
+	case []byte:
+		valueString = fmt.Sprintf("'%s'", strings.ReplaceAll(string(value), "'", "''"))
+	case string:
+		valueString = fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+	case time.Time:
+		valueString = fmt.Sprintf("'%s'", value.UTC().Format(TableTimeLayout))
+	default:
+		valueString, err = SqlFieldValueToString(reflect.TypeOf(value).Kind(), reflect.ValueOf(value))
+	}
+
+	return
+}
+
 //--------------------------------------------------------------------------------//
@@ -0,0 +1,314 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	TableRelationKindHasOne    = "HAS_ONE"
+	TableRelationKindHasMany   = "HAS_MANY"
+	TableRelationKindBelongsTo = "BELONGS_TO"
+)
+
+var (
+	TableRelationPreloadBlock int64 = 500
+)
+
+//--------------------------------------------------------------------------------//
+
+type TableRelation struct {
+	goName  string
+	goField reflect.StructField
+	goType  reflect.Type
+
+	relationKind   string
+	relationTable  string
+	relationColumn *string
+}
+
+//--------------------------------------------------------------------------------//
+
+func (relation *TableRelation) GetGoName() string {
+	return relation.goName
+}
+
+func (relation *TableRelation) GetGoType() reflect.Type {
+	return relation.goType
+}
+
+func (relation *TableRelation) GetKind() string {
+	return relation.relationKind
+}
+
+func (relation *TableRelation) GetTableName() string {
+	return relation.relationTable
+}
+
+func (relation *TableRelation) GetColumnName() *string {
+	return relation.relationColumn
+}
+
+//--------------------------------------------------------------------------------//
+
+func (relation *TableRelation) helperKey(table *Table, relationTable *Table) (tableField *TableField, relationField *TableField, err error) {
+	switch relation.relationKind {
+	case TableRelationKindBelongsTo:
+		tableField = table.GetFieldBySqlName(*relation.relationColumn)
+		relationField = relationTable.GetFieldBySqlName(*tableField.ReferenceColumn())
+	default:
+		if relation.relationColumn != nil {
+			relationField = relationTable.GetFieldBySqlName(*relation.relationColumn)
+		} else {
+			for _, referenceField := range relationTable.GetReferenceArray() {
+				if *referenceField.ReferenceTable() != table.GetSqlName() {
+					continue
+				}
+
+				if relationField != nil {
+					err = fmt.Errorf("%w: %s has several references to %s", ErrorTableFieldHasInvalidRelation, relationTable.GetSqlName(), table.GetSqlName())
+					return
+				}
+
+				relationField = referenceField
+			}
+		}
+
+		if relationField == nil {
+			break
+		}
+
+		if relationField.ReferenceColumn() != nil {
+			tableField = table.GetFieldBySqlName(*relationField.ReferenceColumn())
+		} else if len(table.GetPrimaryKeyArray()) == 1 {
+			tableField = table.GetPrimaryKeyArray()[0]
+		}
+	}
+
+	if tableField == nil || relationField == nil {
+		err = fmt.Errorf("%w: %s.%s", ErrorTableFieldHasInvalidRelation, table.GetGoName(), relation.goName)
+	}
+
+	return
+}
+
+func (relation *TableRelation) helperAssign(tableValue reflect.Value, relationArray []reflect.Value) {
+	fieldValue := tableValue.FieldByIndex(relation.goField.Index)
+
+	switch relation.relationKind {
+	case TableRelationKindHasMany:
+		fieldSlice := reflect.MakeSlice(fieldValue.Type(), 0, len(relationArray))
+
+		for _, relationValue := range relationArray {
+			fieldSlice = reflect.Append(fieldSlice, relationValue)
+		}
+
+		fieldValue.Set(fieldSlice)
+	default:
+		if len(relationArray) == 0 {
+			fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			return
+		}
+
+		if fieldValue.Kind() == reflect.Ptr {
+			relationPtr := reflect.New(relation.goType)
+			relationPtr.Elem().Set(relationArray[0])
+			fieldValue.Set(relationPtr)
+		} else {
+			fieldValue.Set(relationArray[0])
+		}
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperRelationValueKey(fieldValue reflect.Value) (interface{}, string, bool) {
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			return nil, "", false
+		}
+
+		fieldValue = fieldValue.Elem()
+	}
+
+	return fieldValue.Interface(), fmt.Sprint(fieldValue.Interface()), true
+}
+
+func helperRelationPreload(query func(BuilderWithResponse) (interface{}, error), table *Table, response interface{}, preloadArray []string) (err error) {
+	var (
+		responseArray   = reflect.ValueOf(response)
+		preloadNameList = []string{}
+		preloadNestMap  = map[string][]string{}
+	)
+
+	for _, preloadPath := range preloadArray {
+		preloadName, preloadNest, _ := strings.Cut(preloadPath, ".")
+
+		if _, ok := preloadNestMap[preloadName]; !ok {
+			preloadNameList = append(preloadNameList, preloadName)
+			preloadNestMap[preloadName] = []string{}
+		}
+
+		if len(preloadNest) > 0 {
+			preloadNestMap[preloadName] = append(preloadNestMap[preloadName], preloadNest)
+		}
+	}
+
+	for _, preloadName := range preloadNameList {
+		var (
+			relationTable *Table
+			tableField    *TableField
+			relationField *TableField
+			relationValue reflect.Value
+			keyArray      []interface{}
+			keyMap        = map[string]bool{}
+			relationMap   = map[string][]reflect.Value{}
+		)
+
+		relation := table.GetRelation(preloadName)
+		if relation == nil {
+			return fmt.Errorf("%w: %s.%s", ErrorTableHasUnknownRelation, table.GetGoName(), preloadName)
+		}
+
+		relationTable, err = NewTable(relation.relationTable, reflect.Zero(relation.goType).Interface())
+		if err != nil {
+			return
+		}
+
+		tableField, relationField, err = relation.helperKey(table, relationTable)
+		if err != nil {
+			return
+		}
+
+		for responseIndex := 0; responseIndex < responseArray.Len(); responseIndex++ {
+			keyValue, keyString, ok := helperRelationValueKey(tableField.helperValue(responseArray.Index(responseIndex)))
+			if ok && !keyMap[keyString] {
+				keyMap[keyString] = true
+				keyArray = append(keyArray, keyValue)
+			}
+		}
+
+		relationValue = reflect.MakeSlice(reflect.SliceOf(relation.goType), 0, 0)
+
+		for keyIndex := int64(0); keyIndex < int64(len(keyArray)); keyIndex += TableRelationPreloadBlock {
+			var relationResponse interface{}
+
+			keyBlock := keyArray[keyIndex:]
+			if int64(len(keyBlock)) > TableRelationPreloadBlock {
+				keyBlock = keyBlock[:TableRelationPreloadBlock]
+			}
+
+			relationResponse, err = query(NewBuilderSelect(relationTable).WhereCondition(NewConditionIn(relationField.GetSqlName(), keyBlock...)))
+			if err != nil {
+				return
+			}
+
+			relationValue = reflect.AppendSlice(relationValue, reflect.ValueOf(relationResponse).Convert(relationValue.Type()))
+		}
+
+		if len(preloadNestMap[preloadName]) > 0 {
+			err = helperRelationPreload(query, relationTable, relationValue.Interface(), preloadNestMap[preloadName])
+			if err != nil {
+				return
+			}
+		}
+
+		for relationIndex := 0; relationIndex < relationValue.Len(); relationIndex++ {
+			_, keyString, ok := helperRelationValueKey(relationField.helperValue(relationValue.Index(relationIndex)))
+			if ok {
+				relationMap[keyString] = append(relationMap[keyString], relationValue.Index(relationIndex))
+			}
+		}
+
+		for responseIndex := 0; responseIndex < responseArray.Len(); responseIndex++ {
+			_, keyString, _ := helperRelationValueKey(tableField.helperValue(responseArray.Index(responseIndex)))
+			relation.helperAssign(responseArray.Index(responseIndex), relationMap[keyString])
+		}
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func registerTableRelation(table *Table, reflectStructField reflect.StructField) (bool, error) {
+	goFieldTag, ok := reflectStructField.Tag.Lookup("sql")
+	if !ok {
+		return false, nil
+	}
+
+	relation := &TableRelation{
+		goName:  reflectStructField.Name,
+		goField: reflectStructField,
+		goType:  reflectStructField.Type,
+	}
+
+	for _, sqlTagOption := range strings.Split(goFieldTag, "|") {
+		sqlTagName, sqlTagValue, _ := strings.Cut(strings.TrimSpace(sqlTagOption), "=")
+
+		switch sqlTagName {
+		case TableRelationKindHasOne, TableRelationKindHasMany, TableRelationKindBelongsTo:
+			if len(relation.relationKind) > 0 || len(sqlTagValue) == 0 {
+				return false, fmt.Errorf("%w: %s.%s", ErrorTableFieldHasInvalidRelation, table.GetGoName(), relation.goName)
+			}
+
+			relation.relationKind = sqlTagName
+
+			if referenceMatch := tableFieldReferenceRegexp.FindStringSubmatch(sqlTagValue); referenceMatch != nil && sqlTagName != TableRelationKindBelongsTo {
+				relation.relationTable = referenceMatch[1]
+				relation.relationColumn = &referenceMatch[2]
+			} else if sqlTagName == TableRelationKindBelongsTo {
+				relation.relationColumn = &sqlTagValue
+			} else {
+				relation.relationTable = sqlTagValue
+			}
+		}
+	}
+
+	if len(relation.relationKind) == 0 {
+		return false, nil
+	}
+
+	if relation.relationKind == TableRelationKindHasMany {
+		if relation.goType.Kind() != reflect.Slice {
+			return false, fmt.Errorf("%w: %s.%s must be a slice", ErrorTableFieldHasInvalidRelation, table.GetGoName(), relation.goName)
+		}
+
+		relation.goType = relation.goType.Elem()
+	} else if relation.goType.Kind() == reflect.Ptr {
+		relation.goType = relation.goType.Elem()
+	}
+
+	if relation.goType.Kind() != reflect.Struct {
+		return false, fmt.Errorf("%w: %s.%s must be a struct", ErrorTableFieldHasInvalidRelation, table.GetGoName(), relation.goName)
+	}
+
+	table.goRelationNameArray = append(table.goRelationNameArray, relation.goName)
+	table.goRelationMap[relation.goName] = relation
+
+	return true, nil
+}
+
+func resolveTableRelation(table *Table) error {
+	for _, relationGoName := range table.goRelationNameArray {
+		relation := table.goRelationMap[relationGoName]
+
+		if relation.relationKind != TableRelationKindBelongsTo {
+			continue
+		}
+
+		tableField := table.GetFieldBySqlName(*relation.relationColumn)
+		if tableField == nil || tableField.ReferenceTable() == nil {
+			return fmt.Errorf("%w: %s.%s without REFERENCES", ErrorTableFieldHasInvalidRelation, table.GetGoName(), relation.goName)
+		}
+
+		relation.relationTable = *tableField.ReferenceTable()
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
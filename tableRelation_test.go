@@ -0,0 +1,170 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testRelationCustomer struct {
+	Id      int64                `sql:"NAME=id | PRIMARY_KEY"`
+	Name    string               `sql:"NAME=name"`
+	Profile *testRelationProfile `sql:"HAS_ONE=profiles"`
+	Orders  []testRelationOrder  `sql:"HAS_MANY=orders(customer_id)"`
+}
+
+type testRelationProfile struct {
+	Id         int64  `sql:"NAME=id | PRIMARY_KEY"`
+	CustomerId int64  `sql:"NAME=customer_id | REFERENCES=customers(id)"`
+	Bio        string `sql:"NAME=bio"`
+}
+
+type testRelationOrder struct {
+	Id         int64                 `sql:"NAME=id | PRIMARY_KEY"`
+	CustomerId int64                 `sql:"NAME=customer_id | REFERENCES=customers(id)"`
+	Customer   *testRelationCustomer `sql:"BELONGS_TO=customer_id"`
+	Items      []testRelationItem    `sql:"HAS_MANY=items"`
+}
+
+type testRelationItem struct {
+	Id      int64  `sql:"NAME=id | PRIMARY_KEY"`
+	OrderId int64  `sql:"NAME=order_id | REFERENCES=orders(id)"`
+	Title   string `sql:"NAME=title"`
+}
+
+func testRelationOpen(t *testing.T) (*Database, *Table, *Table) {
+	t.Helper()
+
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "relation.db"), 1, nil)
+
+	customerTable, err := database.RegisterTable("customers", testRelationCustomer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profileTable, err := database.RegisterTable("profiles", testRelationProfile{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderTable, err := database.RegisterTable("orders", testRelationOrder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itemTable, err := database.RegisterTable("items", testRelationItem{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, builder := range []Builder{
+		NewBuilderInsert(customerTable).Value(
+			testRelationCustomer{Id: 1, Name: "a"},
+			testRelationCustomer{Id: 2, Name: "b"},
+		),
+		NewBuilderInsert(profileTable).Value(
+			testRelationProfile{Id: 1, CustomerId: 1, Bio: "first"},
+		),
+		NewBuilderInsert(orderTable).Value(
+			testRelationOrder{Id: 1, CustomerId: 1},
+			testRelationOrder{Id: 2, CustomerId: 1},
+			testRelationOrder{Id: 3, CustomerId: 2},
+		),
+		NewBuilderInsert(itemTable).Value(
+			testRelationItem{Id: 1, OrderId: 1, Title: "x"},
+			testRelationItem{Id: 2, OrderId: 1, Title: "y"},
+			testRelationItem{Id: 3, OrderId: 2, Title: "z"},
+		),
+	} {
+		err = database.Execute(builder)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return database, customerTable, orderTable
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTableRelationColumn(t *testing.T) {
+	table, err := NewTable("customers", testRelationCustomer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fieldCount := len(table.GetSqlFieldNameArray()); fieldCount != 2 {
+		t.Errorf("customers table has %d columns, want the relation fields left out", fieldCount)
+	}
+
+	if relationCount := len(table.GetRelationNameArray()); relationCount != 2 {
+		t.Errorf("customers table has %d relations, want 2", relationCount)
+	}
+}
+
+func TestTableRelationPreload(t *testing.T) {
+	database, customerTable, _ := testRelationOpen(t)
+
+	response, err := database.Query(NewBuilderSelect(customerTable).Preload("Profile", "Orders", "Orders.Items"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	customerArray := response.([]testRelationCustomer)
+	if len(customerArray) != 2 {
+		t.Fatalf("query returned %d customers, want 2", len(customerArray))
+	}
+
+	if customerArray[0].Profile == nil || customerArray[0].Profile.Bio != "first" {
+		t.Errorf("customer a has profile %v, want the first profile", customerArray[0].Profile)
+	}
+
+	if customerArray[1].Profile != nil {
+		t.Errorf("customer b has profile %v, want none", customerArray[1].Profile)
+	}
+
+	if len(customerArray[0].Orders) != 2 || len(customerArray[1].Orders) != 1 {
+		t.Fatalf("customers have %d and %d orders, want 2 and 1", len(customerArray[0].Orders), len(customerArray[1].Orders))
+	}
+
+	if itemCount := len(customerArray[0].Orders[0].Items); itemCount != 2 {
+		t.Errorf("first order has %d items, want 2", itemCount)
+	}
+
+	if itemCount := len(customerArray[1].Orders[0].Items); itemCount != 0 {
+		t.Errorf("third order has %d items, want 0", itemCount)
+	}
+}
+
+func TestTableRelationPreloadBelongsTo(t *testing.T) {
+	database, _, orderTable := testRelationOpen(t)
+
+	preloadBlock := TableRelationPreloadBlock
+	TableRelationPreloadBlock = 1
+
+	t.Cleanup(func() {
+		TableRelationPreloadBlock = preloadBlock
+	})
+
+	response, err := database.Query(NewBuilderSelect(orderTable).Preload("Customer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, order := range response.([]testRelationOrder) {
+		if order.Customer == nil || order.Customer.Id != order.CustomerId {
+			t.Errorf("order %d has customer %v, want customer %d", order.Id, order.Customer, order.CustomerId)
+		}
+	}
+
+	_, err = database.Query(NewBuilderSelect(orderTable).Preload("Unknown"))
+	if !errors.Is(err, ErrorTableHasUnknownRelation) {
+		t.Errorf("preloading an unknown relation returned %v, want an unknown relation error", err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
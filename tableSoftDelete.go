@@ -0,0 +1,60 @@
+package sqlctrl
+
+import "reflect"
+
+//--------------------------------------------------------------------------------//
+
+const (
+	tableSoftDeleteScopeDefault = iota
+	tableSoftDeleteScopeWith
+	tableSoftDeleteScopeOnly
+)
+
+//--------------------------------------------------------------------------------//
+
+func (table *Table) helperSoftDeleteCondition(isDeleted bool) *Condition {
+	softDelete := table.GetSoftDelete()
+	if softDelete == nil {
+		return nil
+	}
+
+	if softDelete.goType == reflect.Bool {
+		return NewConditionEqual(softDelete.sqlName, isDeleted)
+	}
+
+	if isDeleted {
+		return NewConditionIsNotNull(softDelete.sqlName)
+	}
+
+	return NewConditionIsNull(softDelete.sqlName)
+}
+
+func (table *Table) helperSoftDeleteValue() interface{} {
+	softDelete := table.GetSoftDelete()
+	if softDelete == nil {
+		return nil
+	}
+
+	if softDelete.goType == reflect.Bool {
+		return true
+	}
+
+	return softDelete.helperTimeValue(table.helperTimeNow()).Interface()
+}
+
+func (table *Table) helperSoftDeleteScope(whereConditionArray []*Condition, softDeleteScope int) []*Condition {
+	if table == nil || table.GetSoftDelete() == nil {
+		return whereConditionArray
+	}
+
+	switch softDeleteScope {
+	case tableSoftDeleteScopeDefault:
+		return append(append([]*Condition{}, whereConditionArray...), table.helperSoftDeleteCondition(false))
+	case tableSoftDeleteScopeOnly:
+		return append(append([]*Condition{}, whereConditionArray...), table.helperSoftDeleteCondition(true))
+	default:
+		return whereConditionArray
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,135 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testSoftDeleteFlag struct {
+	Id      int64 `sql:"NAME=id | PRIMARY_KEY"`
+	Deleted bool  `sql:"NAME=deleted | SOFT_DELETE"`
+}
+
+type testSoftDeleteTime struct {
+	Id        int64      `sql:"NAME=id | PRIMARY_KEY"`
+	DeletedAt *time.Time `sql:"NAME=deleted_at | SOFT_DELETE"`
+}
+
+type testSoftDeleteInvalid struct {
+	Id      int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Deleted string `sql:"NAME=deleted | SOFT_DELETE"`
+}
+
+func testSoftDeleteOpen(t *testing.T, tableStruct interface{}, valueArray ...interface{}) (*Database, *Table) {
+	t.Helper()
+
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "delete.db"), 1, nil)
+
+	table, err := database.RegisterTable("records", tableStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(valueArray...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table
+}
+
+func testSoftDeleteCount(t *testing.T, database *Database, builder *BuilderSelect) int {
+	t.Helper()
+
+	response, err := database.Query(builder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return reflect.ValueOf(response).Len()
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTableSoftDeleteInvalid(t *testing.T) {
+	_, err := NewTable("records", testSoftDeleteInvalid{})
+	if !errors.Is(err, ErrorTableFieldHasInvalidSoftDelete) {
+		t.Errorf("SOFT_DELETE on a string returned %v, want an invalid soft delete error", err)
+	}
+}
+
+func TestTableSoftDelete(t *testing.T) {
+	database, table := testSoftDeleteOpen(t, testSoftDeleteFlag{},
+		testSoftDeleteFlag{Id: 1},
+		testSoftDeleteFlag{Id: 2},
+		testSoftDeleteFlag{Id: 3},
+	)
+
+	err := database.Execute(NewBuilderDelete(table).WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderDelete(table).WhereCondition(NewConditionEqual("id", 2)).HardDelete())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recordCount := testSoftDeleteCount(t, database, NewBuilderSelect(table)); recordCount != 1 {
+		t.Errorf("default scope returned %d records, want 1", recordCount)
+	}
+
+	if recordCount := testSoftDeleteCount(t, database, NewBuilderSelect(table).WithDeleted()); recordCount != 2 {
+		t.Errorf("WithDeleted returned %d records, want the soft-deleted record kept", recordCount)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table).OnlyDeleted())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recordArray := response.([]testSoftDeleteFlag); len(recordArray) != 1 || recordArray[0].Id != 1 || !recordArray[0].Deleted {
+		t.Errorf("OnlyDeleted returned %v, want the soft-deleted record", recordArray)
+	}
+}
+
+func TestTableSoftDeleteTime(t *testing.T) {
+	timeNow := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	database, table := testSoftDeleteOpen(t, testSoftDeleteTime{},
+		testSoftDeleteTime{Id: 1},
+		testSoftDeleteTime{Id: 2},
+	)
+
+	table.SetClock(func() time.Time {
+		return timeNow
+	})
+
+	err := database.Execute(NewBuilderDelete(table).WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table).OnlyDeleted())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordArray := response.([]testSoftDeleteTime)
+	if len(recordArray) != 1 || recordArray[0].DeletedAt == nil || !recordArray[0].DeletedAt.Equal(timeNow) {
+		t.Errorf("OnlyDeleted returned %v, want record 1 deleted at %v", recordArray, timeNow)
+	}
+
+	if recordCount := testSoftDeleteCount(t, database, NewBuilderSelect(table)); recordCount != 1 {
+		t.Errorf("default scope returned %d records, want 1", recordCount)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,226 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	TableTimeLayout = "2006-01-02 15:04:05.000000"
+)
+
+var (
+	tableTimeType        = reflect.TypeOf(time.Time{})
+	tableTimeLayoutArray = []string{
+		TableTimeLayout,
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+	}
+)
+
+//--------------------------------------------------------------------------------//
+
+type tableFieldTime struct {
+	fieldValue reflect.Value
+}
+
+func (fieldTime *tableFieldTime) Scan(value interface{}) (err error) {
+	var valueTime time.Time
+
+	fieldTime.fieldValue.Set(reflect.Zero(fieldTime.fieldValue.Type()))
+
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		valueTime = value
+	case []byte:
+		valueTime, err = helperTimeParse(string(value))
+	case string:
+		valueTime, err = helperTimeParse(value)
+	default:
+		err = fmt.Errorf("%w: %T", ErrorTableFieldHasInvalidTime, value)
+	}
+
+	if err != nil {
+		return
+	}
+
+	helperTimeAssign(fieldTime.fieldValue, reflect.ValueOf(valueTime.UTC()))
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (table *Table) helperTimeNow() time.Time {
+	if table.clock != nil {
+		return table.clock()
+	}
+
+	return time.Now()
+}
+
+func (field *TableField) helperTimeSetRegexp() *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf("^\\s*`?%s`?\\s*=", regexp.QuoteMeta(field.sqlName)))
+}
+
+func (field *TableField) helperTimeValue(timeNow time.Time) reflect.Value {
+	if field.isTime {
+		return reflect.ValueOf(timeNow.UTC())
+	}
+
+	return reflect.ValueOf(timeNow.Unix())
+}
+
+func (table *Table) helperTimeStamp(valueUnit interface{}, isCreate bool) interface{} {
+	var valueStamped reflect.Value
+
+	valueReflectValue := reflect.ValueOf(valueUnit)
+	if !valueReflectValue.IsValid() || valueReflectValue.Type() != table.goType {
+		return valueUnit
+	}
+
+	timeNow := table.helperTimeNow()
+
+	for _, fieldGoName := range table.goFieldNameArray {
+		tableField := table.goFieldMap[fieldGoName]
+
+		if !tableField.isAutoUpdateTime && !(isCreate && tableField.isAutoCreateTime && tableField.helperValue(valueReflectValue).IsZero()) {
+			continue
+		}
+
+		if !valueStamped.IsValid() {
+			valueStamped = reflect.New(table.goType).Elem()
+			valueStamped.Set(valueReflectValue)
+		}
+
+		helperTimeAssign(tableField.helperValue(valueStamped), tableField.helperTimeValue(timeNow))
+	}
+
+	if !valueStamped.IsValid() {
+		return valueUnit
+	}
+
+	return valueStamped.Interface()
+}
+
+func (table *Table) helperTimeStampArray(valueArray []interface{}, isCreate bool) []interface{} {
+	if table == nil {
+		return valueArray
+	}
+
+	valueStampedArray := make([]interface{}, 0, len(valueArray))
+	for _, valueUnit := range valueArray {
+		valueStampedArray = append(valueStampedArray, table.helperTimeStamp(valueUnit, isCreate))
+	}
+
+	return valueStampedArray
+}
+
+func (table *Table) helperTimeUpdateArray(setStringArray []string, setFieldNameArray []string) (fieldNameArray []string, valueArray []interface{}) {
+	if table == nil {
+		return
+	}
+
+	timeNow := table.helperTimeNow()
+
+	for _, fieldGoName := range table.goFieldNameArray {
+		tableField := table.goFieldMap[fieldGoName]
+
+		if !tableField.isAutoUpdateTime {
+			continue
+		}
+
+		fieldSet := false
+
+		for _, setFieldName := range setFieldNameArray {
+			fieldSet = fieldSet || setFieldName == tableField.sqlName
+		}
+
+		for _, setString := range setStringArray {
+			fieldSet = fieldSet || tableField.timeSetRegexp.MatchString(setString)
+		}
+
+		if !fieldSet {
+			fieldNameArray = append(fieldNameArray, tableField.sqlName)
+			valueArray = append(valueArray, tableField.helperTimeValue(timeNow).Interface())
+		}
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperTimeParse(value string) (valueTime time.Time, err error) {
+	value = strings.TrimSpace(value)
+
+	for _, timeLayout := range tableTimeLayoutArray {
+		valueTime, err = time.Parse(timeLayout, value)
+		if err == nil {
+			return
+		}
+	}
+
+	err = fmt.Errorf("%w: %q", ErrorTableFieldHasInvalidTime, value)
+	return
+}
+
+func helperTimeAssign(target reflect.Value, value reflect.Value) {
+	targetType := target.Type()
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	value = value.Convert(targetType)
+
+	if target.Kind() == reflect.Ptr {
+		targetValue := reflect.New(targetType)
+		targetValue.Elem().Set(value)
+		target.Set(targetValue)
+	} else {
+		target.Set(value)
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func SqlFieldValueToTime(reflectValue reflect.Value) (value interface{}, err error) {
+	switch reflectValue.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Ptr, reflect.Interface:
+		if reflectValue.IsNil() {
+			return nil, nil
+		}
+
+		return SqlFieldValueToTime(reflectValue.Elem())
+	case reflect.String:
+		return reflectValue.String(), nil
+	}
+
+	if reflectValue.Type() != tableTimeType {
+		return nil, fmt.Errorf("%w: %s", ErrorTableFieldHasInvalidTime, reflectValue.Type())
+	}
+
+	return reflectValue.Interface().(time.Time).UTC().Format(TableTimeLayout), nil
+}
+
+func SqlFieldTimeToString(reflectValue reflect.Value) (valueString string, err error) {
+	value, err := SqlFieldValueToTime(reflectValue)
+	if err != nil || value == nil {
+		return "NULL", err
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value.(string), "'", "''")), nil
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,111 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testTimeRecord struct {
+	Id        int64     `sql:"NAME=id | PRIMARY_KEY"`
+	Name      string    `sql:"NAME=name"`
+	CreatedAt time.Time `sql:"NAME=created_at | AUTO_CREATE_TIME"`
+	UpdatedAt int64     `sql:"NAME=updated_at | AUTO_UPDATE_TIME"`
+}
+
+func testTimeQuery(t *testing.T, database *Database, table *Table) testTimeRecord {
+	t.Helper()
+
+	response, err := database.Query(NewBuilderSelect(table).WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordArray := response.([]testTimeRecord)
+	if len(recordArray) != 1 {
+		t.Fatalf("query returned %d records, want 1", len(recordArray))
+	}
+
+	return recordArray[0]
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTableTimeClock(t *testing.T) {
+	var (
+		timeCreate = time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+		timeUpdate = timeCreate.Add(time.Hour)
+		timeNow    = timeCreate
+	)
+
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "time.db"), 1, nil)
+	database.SetClock(func() time.Time {
+		return timeNow
+	})
+
+	table, err := database.RegisterTable("records", testTimeRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testTimeRecord{Id: 1, Name: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := testTimeQuery(t, database, table)
+	if !record.CreatedAt.Equal(timeCreate) || record.UpdatedAt != timeCreate.Unix() {
+		t.Errorf("inserted record has times %v and %d, want %v", record.CreatedAt, record.UpdatedAt, timeCreate)
+	}
+
+	timeNow = timeUpdate
+
+	err = database.Execute(NewBuilderUpdate(table).SetValue("name", "b").WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record = testTimeQuery(t, database, table)
+	if !record.CreatedAt.Equal(timeCreate) || record.UpdatedAt != timeUpdate.Unix() {
+		t.Errorf("updated record has times %v and %d, want the update time stamped only", record.CreatedAt, record.UpdatedAt)
+	}
+
+	err = database.Execute(NewBuilderUpdate(table).SetValue("updated_at", 1).WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record = testTimeQuery(t, database, table); record.UpdatedAt != 1 {
+		t.Errorf("explicitly assigned update time was overwritten with %d", record.UpdatedAt)
+	}
+}
+
+func TestTableTimeClockTable(t *testing.T) {
+	timeNow := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	table, err := NewTable("records", testTimeRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table.SetClock(func() time.Time {
+		return timeNow
+	})
+
+	result, _, err := NewBuilderInsert(table).Value(testTimeRecord{Id: 1}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(result, fmt.Sprint(timeNow.Unix())) {
+		t.Errorf("insert built as %q, want the table clock stamped", result)
+	}
+}
+
+//--------------------------------------------------------------------------------//
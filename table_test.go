@@ -0,0 +1,203 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testReferenceAuthor struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+}
+
+type testReferenceAuthorV2 struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name | UNIQUE"`
+}
+
+type testReferenceBook struct {
+	Id       int64  `sql:"NAME=id | PRIMARY_KEY"`
+	AuthorId int64  `sql:"NAME=author_id | REFERENCES=authors(id) | ON_DELETE=cascade"`
+	Title    string `sql:"NAME=title"`
+}
+
+type testReferenceInvalid struct {
+	Id       int64 `sql:"NAME=id | PRIMARY_KEY"`
+	AuthorId int64 `sql:"NAME=author_id | ON_DELETE=cascade"`
+}
+
+// FlattenAudit is exported because NewTable rejects unexported embedded structs.
+type FlattenAudit struct {
+	CreatedBy string `sql:"NAME=created_by"`
+	UpdatedBy string `sql:"NAME=updated_by"`
+}
+
+type testFlattenAddress struct {
+	City string `sql:"NAME=city"`
+	Zip  string `sql:"NAME=zip"`
+}
+
+type testFlattenUser struct {
+	Id int64 `sql:"NAME=id | PRIMARY_KEY"`
+	FlattenAudit
+	Home testFlattenAddress `sql:"PREFIX=home_"`
+	Work testFlattenAddress `sql:"PREFIX=work_"`
+}
+
+type testFlattenDuplicate struct {
+	Id        int64  `sql:"NAME=id | PRIMARY_KEY"`
+	CreatedBy string `sql:"NAME=created_by"`
+	FlattenAudit
+}
+
+func testReferenceOpen(t *testing.T, databasePath string, schemeVersion int64, authorStruct interface{}) (*Database, *Table, *Table) {
+	t.Helper()
+
+	database, _ := testSchemeOpen(t, databasePath, schemeVersion, nil)
+
+	authorTable, err := database.RegisterTable("authors", authorStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bookTable, err := database.RegisterTable("books", testReferenceBook{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, authorTable, bookTable
+}
+
+func testReferenceBookCount(t *testing.T, database *Database, bookTable *Table) int {
+	t.Helper()
+
+	response, err := database.Query(NewBuilderSelect(bookTable))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return len(response.([]testReferenceBook))
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTableFlatten(t *testing.T) {
+	table, err := NewTable("users", testFlattenUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fieldName := range []string{"created_by", "updated_by", "home_city", "home_zip", "work_city", "work_zip"} {
+		if table.GetFieldBySqlName(fieldName) == nil {
+			t.Errorf("users table misses the flattened column %s", fieldName)
+		}
+	}
+
+	if tableField := table.GetFieldByGoName("Home.City"); tableField == nil || tableField.GetSqlName() != "home_city" {
+		t.Errorf("Home.City maps to %v, want home_city", tableField)
+	}
+
+	_, err = NewTable("users", testFlattenDuplicate{})
+	if !errors.Is(err, ErrorTableFieldHasDuplicateName) {
+		t.Errorf("an embedded column clashing with the parent returned %v, want a duplicate name error", err)
+	}
+}
+
+func TestTableFlattenQuery(t *testing.T) {
+	database, _ := testSchemeOpen(t, filepath.Join(t.TempDir(), "flatten.db"), 1, nil)
+
+	table, err := database.RegisterTable("users", testFlattenUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := testFlattenUser{
+		Id:           1,
+		FlattenAudit: FlattenAudit{CreatedBy: "a", UpdatedBy: "b"},
+		Home:         testFlattenAddress{City: "home", Zip: "1"},
+		Work:         testFlattenAddress{City: "work", Zip: "2"},
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(user))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table).WhereCondition(NewConditionEqual("work_city", "work")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if userArray := response.([]testFlattenUser); len(userArray) != 1 || userArray[0] != user {
+		t.Errorf("query returned %v, want %v", userArray, user)
+	}
+}
+
+func TestTableReferenceInvalid(t *testing.T) {
+	_, err := NewTable("books", testReferenceInvalid{})
+	if !errors.Is(err, ErrorTableFieldHasInvalidReference) {
+		t.Errorf("ON_DELETE without REFERENCES returned %v, want an invalid reference error", err)
+	}
+}
+
+func TestTableReference(t *testing.T) {
+	database, authorTable, bookTable := testReferenceOpen(t, filepath.Join(t.TempDir(), "reference.db"), 1, testReferenceAuthor{})
+
+	err := database.Execute(NewBuilderInsert(authorTable).Value(testReferenceAuthor{Id: 1, Name: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(bookTable).Value(testReferenceBook{Id: 1, AuthorId: 1, Title: "kept"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(bookTable).Value(testReferenceBook{Id: 2, AuthorId: 2, Title: "orphan"}))
+	if err == nil {
+		t.Error("insert of a book without its author succeeded")
+	}
+
+	err = database.Execute(NewBuilderDelete(authorTable).WhereCondition(NewConditionEqual("id", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bookCount := testReferenceBookCount(t, database, bookTable); bookCount != 0 {
+		t.Errorf("books table holds %d books after the cascading delete, want 0", bookCount)
+	}
+}
+
+func TestTableReferenceRebuild(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "rebuild.db")
+
+	databaseV1, authorTable, bookTable := testReferenceOpen(t, databasePath, 1, testReferenceAuthor{})
+
+	err := databaseV1.Execute(NewBuilderInsert(authorTable).Value(testReferenceAuthor{Id: 1, Name: "a"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = databaseV1.Execute(NewBuilderInsert(bookTable).Value(testReferenceBook{Id: 1, AuthorId: 1, Title: "kept"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	databaseV2, authorTable, bookTable := testReferenceOpen(t, databasePath, 2, testReferenceAuthorV2{})
+
+	err = databaseV2.Execute(NewBuilderInsert(authorTable).Value(testReferenceAuthorV2{Id: 2, Name: "a"}))
+	if err == nil {
+		t.Error("authors table was not rebuilt with the unique name")
+	}
+
+	if bookCount := testReferenceBookCount(t, databaseV2, bookTable); bookCount != 1 {
+		t.Errorf("books table holds %d books after the authors rebuild, want 1", bookCount)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -1,9 +1,5 @@
 package sqlctrl
 
-import (
-	"fmt"
-)
-
 // --------------------------------------------------------------------------------//
 
 var (
@@ -31,8 +27,20 @@ func (transaction *Transaction) Execute(builderRequest Builder) error {
 	return transaction.transport.TransactionExecute(builderRequest)
 }
 
-func (transaction *Transaction) Query(builderRequest BuilderWithResponse) (interface{}, error) {
-	return transaction.transport.TransactionQuery(builderRequest)
+func (transaction *Transaction) Query(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	response, err = transaction.transport.TransactionQuery(builderRequest)
+	if err != nil {
+		return
+	}
+
+	switch v := builderRequest.(type) {
+	case *BuilderSelect:
+		if len(v.preloadArray) > 0 {
+			err = helperRelationPreload(transaction.transport.TransactionQuery, v.selectTable, response, v.preloadArray)
+		}
+	}
+
+	return
 }
 
 func (transaction *Transaction) GetIndexLast() int64 {
@@ -56,24 +64,12 @@ func (transaction *Transaction) ExecuteCreateTable(createTable *Table) error {
 	return transaction.Execute(NewBuilderCreate(createTable).IfNotExists(false))
 }
 
-func (transaction *Transaction) ExecuteDropTable(dropTable *Table) (err error) {
+func (transaction *Transaction) ExecuteDropTable(dropTable *Table) error {
 	if dropTable == nil {
 		return ErrorTableIsNil
 	}
 
-	sqlTx := transaction.transport.LockSqlTx()
-	defer transaction.transport.Unlock()
-
-	if sqlTx == nil {
-		return ErrorTransactionIsAlreadyClosed
-	}
-
-	_, err = sqlTx.Exec(fmt.Sprintf("DROP TABLE IF EXISTS `%s`", dropTable.GetSqlName()))
-	if err != nil {
-		return
-	}
-
-	return
+	return transaction.Execute(NewBuilderDrop(dropTable).IfExists(true))
 }
 
 func (transaction *Transaction) ExecuteDeleteTable(deleteTable *Table) error {
@@ -108,13 +104,18 @@ func (transaction *Transaction) ExecuteInsertValue(insertTable *Table, insertVal
 func (transaction *Transaction) ExecuteReplaceValue(replaceTable *Table, replaceValueArray ...interface{}) (err error) {
 	var (
 		replaceOffset  int64
+		replaceBlock   = TransactionReplaceBlock
 		replaceBuilder *BuilderReplace
 	)
 
+	if replaceTable != nil && replaceTable.GetVersion() != nil {
+		replaceBlock = 1
+	}
+
 	replaceBuilder = NewBuilderReplace(replaceTable)
 
-	for replaceOffset = 0; replaceOffset < int64(len(replaceValueArray)); replaceOffset += TransactionReplaceBlock {
-		replaceOffsetNext := replaceOffset + TransactionReplaceBlock
+	for replaceOffset = 0; replaceOffset < int64(len(replaceValueArray)); replaceOffset += replaceBlock {
+		replaceOffsetNext := replaceOffset + replaceBlock
 		if int64(len(replaceValueArray)) <= replaceOffsetNext {
 			replaceOffsetNext = int64(len(replaceValueArray))
 		}
@@ -2,6 +2,7 @@ package sqlctrl
 
 import (
 	"database/sql"
+	"fmt"
 )
 
 //--------------------------------------------------------------------------------//
@@ -13,6 +14,7 @@ type Transport interface {
 	Unlock()
 
 	TransportRegister(*Database) error
+	GetDialect() string
 	Open() error
 	Close() error
 	Execute(Builder) error
@@ -26,4 +28,48 @@ type Transport interface {
 	TransactionQuery(BuilderWithResponse) (interface{}, error)
 }
 
+type transportWithExecuteStatus interface {
+	executeStatus() (int64, int64)
+}
+
+type transportWithTableIndex interface {
+	queryTableIndex(*Table, string) (int64, error)
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperExecuteStatus(transport Transport) (indexLast int64, changeCount int64) {
+	switch v := transport.(type) {
+	case transportWithExecuteStatus:
+		return v.executeStatus()
+	}
+
+	return
+}
+
+func helperQueryTableIndex(transport Transport, table *Table, sqlAggregate string) (tableIndex int64, err error) {
+	switch v := transport.(type) {
+	case transportWithTableIndex:
+		return v.queryTableIndex(table, sqlAggregate)
+	}
+
+	sqlDb := transport.LockSqlDb()
+	defer transport.Unlock()
+
+	if sqlDb == nil {
+		err = ErrorTransportIsAlreadyClosed
+		return
+	}
+
+	dbRow := sqlDb.QueryRow(fmt.Sprintf("SELECT %s(`%s`) FROM `%s`", sqlAggregate, table.GetAutoIncrement().GetSqlName(), table.GetSqlName()))
+
+	err = dbRow.Err()
+	if err != nil {
+		return
+	}
+
+	dbRow.Scan(&tableIndex)
+	return
+}
+
 //--------------------------------------------------------------------------------//
@@ -0,0 +1,378 @@
+package sqlctrl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/rand"
+	"regexp"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	transportFaultRuleExecute = iota
+	transportFaultRuleMatch
+	transportFaultRuleRandom
+	transportFaultRuleCommit
+)
+
+type transportFaultRule struct {
+	ruleType        int
+	ruleIndex       int64
+	rulePattern     *regexp.Regexp
+	ruleProbability float64
+	ruleError       error
+}
+
+//--------------------------------------------------------------------------------//
+
+type TransportFault struct {
+	Transport
+	database *Database
+
+	mutex            chan interface{}
+	transport        Transport
+	random           *rand.Rand
+	ruleArray        []*transportFaultRule
+	latency          time.Duration
+	latencyJitter    time.Duration
+	executeCount     int64
+	commitCount      int64
+	transactionError error
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) helperRule(rule *transportFaultRule) *TransportFault {
+	if rule.ruleError == nil {
+		rule.ruleError = driver.ErrBadConn
+	}
+
+	transport.mutex <- true
+	transport.ruleArray = append(transport.ruleArray, rule)
+	<-transport.mutex
+
+	return transport
+}
+
+func (transport *TransportFault) FailExecute(executeIndex int64, err error) *TransportFault {
+	return transport.helperRule(&transportFaultRule{
+		ruleType:  transportFaultRuleExecute,
+		ruleIndex: executeIndex,
+		ruleError: err,
+	})
+}
+
+func (transport *TransportFault) FailMatch(pattern string, err error) *TransportFault {
+	return transport.helperRule(&transportFaultRule{
+		ruleType:    transportFaultRuleMatch,
+		rulePattern: regexp.MustCompile(pattern),
+		ruleError:   err,
+	})
+}
+
+func (transport *TransportFault) FailRandom(probability float64, err error) *TransportFault {
+	return transport.helperRule(&transportFaultRule{
+		ruleType:        transportFaultRuleRandom,
+		ruleProbability: probability,
+		ruleError:       err,
+	})
+}
+
+func (transport *TransportFault) FailCommit(commitIndex int64, err error) *TransportFault {
+	return transport.helperRule(&transportFaultRule{
+		ruleType:  transportFaultRuleCommit,
+		ruleIndex: commitIndex,
+		ruleError: err,
+	})
+}
+
+func (transport *TransportFault) Latency(latency time.Duration, latencyJitter time.Duration) *TransportFault {
+	transport.mutex <- true
+	transport.latency = latency
+	transport.latencyJitter = latencyJitter
+	<-transport.mutex
+
+	return transport
+}
+
+func (transport *TransportFault) Reset() *TransportFault {
+	transport.mutex <- true
+	transport.ruleArray = nil
+	transport.latency = 0
+	transport.latencyJitter = 0
+	transport.executeCount = 0
+	transport.commitCount = 0
+	<-transport.mutex
+
+	return transport
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) GetExecuteCount() int64 {
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	return transport.executeCount
+}
+
+func (transport *TransportFault) GetCommitCount() int64 {
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	return transport.commitCount
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) helperFault(builderRequest Builder, isExecute bool, isCommit bool) (err error) {
+	var (
+		builderString string
+		latency       time.Duration
+	)
+
+	if builderRequest != nil {
+		switch v := builderRequest.(type) {
+		case BuilderWithDialect:
+			v.SetDialect(transport.transport.GetDialect())
+		}
+
+		builderString, _, err = builderRequest.Build()
+		if err != nil {
+			return
+		}
+	}
+
+	transport.mutex <- true
+
+	if isExecute {
+		transport.executeCount++
+	}
+
+	if isCommit {
+		transport.commitCount++
+	}
+
+	latency = transport.latency
+	if transport.latencyJitter > 0 {
+		latency += time.Duration(transport.random.Int63n(int64(transport.latencyJitter)))
+	}
+
+	for _, rule := range transport.ruleArray {
+		switch rule.ruleType {
+		case transportFaultRuleExecute:
+			if isExecute && rule.ruleIndex == transport.executeCount {
+				err = rule.ruleError
+			}
+		case transportFaultRuleMatch:
+			if builderRequest != nil && rule.rulePattern.MatchString(builderString) {
+				err = rule.ruleError
+			}
+		case transportFaultRuleRandom:
+			if !isCommit && transport.random.Float64() < rule.ruleProbability {
+				err = rule.ruleError
+			}
+		case transportFaultRuleCommit:
+			if isCommit && (rule.ruleIndex <= 0 || rule.ruleIndex == transport.commitCount) {
+				err = rule.ruleError
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	<-transport.mutex
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) Lock() {
+	transport.transport.Lock()
+}
+
+func (transport *TransportFault) LockSqlDb() *sql.DB {
+	return transport.transport.LockSqlDb()
+}
+
+func (transport *TransportFault) LockSqlTx() *sql.Tx {
+	return transport.transport.LockSqlTx()
+}
+
+func (transport *TransportFault) Unlock() {
+	transport.transport.Unlock()
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *TransportFault) GetDialect() string {
+	return transport.transport.GetDialect()
+}
+
+func (transport *TransportFault) Open() error {
+	if transport.transport == nil {
+		return ErrorTransportIsNil
+	}
+
+	return transport.transport.Open()
+}
+
+func (transport *TransportFault) Close() error {
+	return transport.transport.Close()
+}
+
+func (transport *TransportFault) Execute(builderRequest Builder) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	err = transport.helperFault(builderRequest, true, false)
+	if err != nil {
+		return
+	}
+
+	return transport.transport.Execute(builderRequest)
+}
+
+func (transport *TransportFault) Query(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	err = transport.helperFault(builderRequest, false, false)
+	if err != nil {
+		return
+	}
+
+	return transport.transport.Query(builderRequest)
+}
+
+func (transport *TransportFault) executeStatus() (int64, int64) {
+	return helperExecuteStatus(transport.transport)
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportFault) TransactionOpen() (*Transaction, error) {
+	_, err := transport.transport.TransactionOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	transport.mutex <- true
+	transport.transactionError = nil
+	<-transport.mutex
+
+	return NewTransaction(transport)
+}
+
+func (transport *TransportFault) TransactionCommit() (err error) {
+	transport.mutex <- true
+	err = transport.transactionError
+	<-transport.mutex
+
+	if err == nil {
+		err = transport.helperFault(nil, false, true)
+	}
+
+	if err != nil {
+		transport.transport.TransactionRollback()
+		return
+	}
+
+	return transport.transport.TransactionCommit()
+}
+
+func (transport *TransportFault) TransactionRollback() error {
+	return transport.transport.TransactionRollback()
+}
+
+func (transport *TransportFault) TransactionStatus() (int64, int64, error) {
+	txIndexLast, txChangeCount, transactionError := transport.transport.TransactionStatus()
+
+	transport.mutex <- true
+	if transport.transactionError != nil {
+		transactionError = transport.transactionError
+	}
+	<-transport.mutex
+
+	return txIndexLast, txChangeCount, transactionError
+}
+
+func (transport *TransportFault) TransactionExecute(builderRequest Builder) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	err = transport.helperFault(builderRequest, true, false)
+	if err != nil {
+		transport.mutex <- true
+		transport.transactionError = err
+		<-transport.mutex
+
+		return
+	}
+
+	return transport.transport.TransactionExecute(builderRequest)
+}
+
+func (transport *TransportFault) TransactionQuery(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	err = transport.helperFault(builderRequest, false, false)
+	if err != nil {
+		return
+	}
+
+	return transport.transport.TransactionQuery(builderRequest)
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportFault(transport Transport, seed int64) *TransportFault {
+	return &TransportFault{
+		mutex:            make(chan interface{}, 1),
+		transport:        transport,
+		random:           rand.New(rand.NewSource(seed)),
+		transactionError: nil,
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,70 @@
+package sqlctrl
+
+import (
+	"errors"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testFaultEvent struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+}
+
+func TestTransportFaultSmoke(t *testing.T) {
+	faultError := errors.New("injected")
+	transport := NewTransportFault(NewTransportMemory(), 1)
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("events", testFaultEvent{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport.FailMatch("INSERT", faultError)
+
+	transaction, err := database.TransactionOpen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.ExecuteInsertValue(table, testFaultEvent{Id: 1, Name: "lost"})
+	if !errors.Is(err, faultError) {
+		t.Errorf("insert returned %v, want the injected error", err)
+	}
+
+	if !errors.Is(transaction.GetError(), faultError) {
+		t.Errorf("transaction error is %v, want the injected error", transaction.GetError())
+	}
+
+	if err = transaction.Commit(); !errors.Is(err, faultError) {
+		t.Errorf("commit returned %v, want the injected error", err)
+	}
+
+	transport.Reset()
+
+	err = database.Execute(NewBuilderInsert(table).Value(testFaultEvent{Id: 2, Name: "kept"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if eventArray := response.([]testFaultEvent); len(eventArray) != 1 || eventArray[0].Id != 2 {
+		t.Errorf("select returned %v, want only the event inserted after the reset", eventArray)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,1296 @@
+package sqlctrl
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+// TRANSPORT MEMORY TABLE
+//--------------------------------------------------------------------------------//
+
+type transportMemoryTable struct {
+	table         *Table
+	rowKeyArray   []string
+	rowMap        map[string]transportRow
+	rowSequence   int64
+	autoIncrement int64
+}
+
+//--------------------------------------------------------------------------------//
+
+func (memoryTable *transportMemoryTable) clone() *transportMemoryTable {
+	memoryTableClone := &transportMemoryTable{
+		table:         memoryTable.table,
+		rowKeyArray:   append([]string{}, memoryTable.rowKeyArray...),
+		rowMap:        make(map[string]transportRow, len(memoryTable.rowMap)),
+		rowSequence:   memoryTable.rowSequence,
+		autoIncrement: memoryTable.autoIncrement,
+	}
+
+	for rowKey, row := range memoryTable.rowMap {
+		memoryTableClone.rowMap[rowKey] = row
+	}
+
+	return memoryTableClone
+}
+
+func (memoryTable *transportMemoryTable) rowKey(row transportRow) (string, bool) {
+	primaryKeyArray := memoryTable.table.GetPrimaryKeyArray()
+	if len(primaryKeyArray) == 0 {
+		return "", false
+	}
+
+	rowKeyArray := []string{}
+	for _, tableField := range primaryKeyArray {
+		rowKeyArray = append(rowKeyArray, fmt.Sprintf("%v", row[tableField.GetSqlName()]))
+	}
+
+	return strings.Join(rowKeyArray, "\x00"), true
+}
+
+func (memoryTable *transportMemoryTable) rowNormalize(row transportRow) (rowNormalized transportRow, err error) {
+	rowNormalized = transportRow{}
+
+	for fieldSqlName := range row {
+		if memoryTable.table.GetFieldBySqlName(fieldSqlName) == nil {
+			err = ErrorTransportMemoryHasUnknownField
+			return
+		}
+	}
+
+	for _, fieldSqlName := range memoryTable.table.GetSqlFieldNameArray() {
+		tableField := memoryTable.table.GetFieldBySqlName(fieldSqlName)
+
+		fieldValue, ok := row[fieldSqlName]
+		if !ok {
+			if tableField.IsAutoIncrement() {
+				memoryTable.autoIncrement++
+				fieldValue = memoryTable.autoIncrement
+			} else if tableField.ValueDefault() != nil {
+				fieldValue = helperMemoryLiteral(*tableField.ValueDefault())
+			}
+		}
+
+		fieldValue, err = helperMemoryConvert(memoryTable.table, tableField, fieldValue)
+		if err != nil {
+			return
+		}
+
+		if tableField.IsAutoIncrement() && fieldValue != nil {
+			autoIncrement, _ := helperValueNumber(fieldValue)
+			if int64(autoIncrement) > memoryTable.autoIncrement {
+				memoryTable.autoIncrement = int64(autoIncrement)
+			}
+		}
+
+		rowNormalized[fieldSqlName] = fieldValue
+	}
+
+	return
+}
+
+func (memoryTable *transportMemoryTable) rowInsert(row transportRow) (rowIndex int64, err error) {
+	row, err = memoryTable.rowNormalize(row)
+	if err != nil {
+		return
+	}
+
+	rowKey, ok := memoryTable.rowKey(row)
+	if !ok {
+		memoryTable.rowSequence++
+		rowKey = fmt.Sprintf("#%d", memoryTable.rowSequence)
+	}
+
+	if _, ok = memoryTable.rowMap[rowKey]; ok {
+		err = ErrorTransportMemoryViolatesPrimaryKey
+		return
+	}
+
+	memoryTable.rowKeyArray = append(memoryTable.rowKeyArray, rowKey)
+	memoryTable.rowMap[rowKey] = row
+
+	if memoryTable.table.GetAutoIncrement() != nil {
+		autoIncrement, _ := helperValueNumber(row[memoryTable.table.GetAutoIncrement().GetSqlName()])
+		rowIndex = int64(autoIncrement)
+	} else {
+		rowIndex = int64(len(memoryTable.rowKeyArray))
+	}
+
+	return
+}
+
+func (memoryTable *transportMemoryTable) rowDelete(rowKey string) {
+	delete(memoryTable.rowMap, rowKey)
+
+	for rowIndex, rowKeyUnit := range memoryTable.rowKeyArray {
+		if rowKeyUnit == rowKey {
+			memoryTable.rowKeyArray = append(memoryTable.rowKeyArray[:rowIndex], memoryTable.rowKeyArray[rowIndex+1:]...)
+			break
+		}
+	}
+}
+
+func (memoryTable *transportMemoryTable) rowReplace(rowKey string, row transportRow) (err error) {
+	rowKeyNext, ok := memoryTable.rowKey(row)
+	if !ok || rowKeyNext == rowKey {
+		memoryTable.rowMap[rowKey] = row
+		return
+	}
+
+	if _, ok = memoryTable.rowMap[rowKeyNext]; ok {
+		return ErrorTransportMemoryViolatesPrimaryKey
+	}
+
+	for rowIndex, rowKeyUnit := range memoryTable.rowKeyArray {
+		if rowKeyUnit == rowKey {
+			memoryTable.rowKeyArray[rowIndex] = rowKeyNext
+			break
+		}
+	}
+
+	delete(memoryTable.rowMap, rowKey)
+	memoryTable.rowMap[rowKeyNext] = row
+
+	return
+}
+
+func (memoryTable *transportMemoryTable) rowVersionReplace(row transportRow) (isFound bool, isChanged bool, err error) {
+	versionField := memoryTable.table.GetVersion()
+	versionName := versionField.GetSqlName()
+
+	row, err = memoryTable.clone().rowNormalize(row)
+	if err != nil {
+		return
+	}
+
+	rowKey, ok := memoryTable.rowKey(row)
+	if !ok {
+		return
+	}
+
+	rowPrevious, isFound := memoryTable.rowMap[rowKey]
+	if !isFound || helperValueCompare(rowPrevious[versionName], row[versionName]) != 0 {
+		return
+	}
+
+	versionValue, _ := helperValueNumber(row[versionName])
+	row[versionName], err = helperMemoryConvert(memoryTable.table, versionField, int64(versionValue)+1)
+	if err != nil {
+		return
+	}
+
+	for _, fieldGoName := range memoryTable.table.GetGoFieldNameArray() {
+		tableField := memoryTable.table.GetFieldByGoName(fieldGoName)
+
+		if tableField.IsAutoIncrement() || tableField.IsAutoCreateTime() {
+			row[tableField.GetSqlName()] = rowPrevious[tableField.GetSqlName()]
+		}
+	}
+
+	err = memoryTable.rowReplace(rowKey, row)
+	isChanged = err == nil
+	return
+}
+
+func (memoryTable *transportMemoryTable) rowConflict(row transportRow) (rowKeyArray []string) {
+	rowKey, ok := memoryTable.rowKey(row)
+	if ok {
+		if _, ok = memoryTable.rowMap[rowKey]; ok {
+			rowKeyArray = append(rowKeyArray, rowKey)
+		}
+	}
+
+	for _, uniqueName := range memoryTable.table.GetUniqueNameArray() {
+		uniqueValue, ok := helperMemoryUniqueValue(memoryTable.table.GetUniqueArray(uniqueName), row)
+		if !ok {
+			continue
+		}
+
+		for _, rowKeyUnit := range memoryTable.rowKeyArray {
+			rowUniqueValue, ok := helperMemoryUniqueValue(memoryTable.table.GetUniqueArray(uniqueName), memoryTable.rowMap[rowKeyUnit])
+			if ok && rowUniqueValue == uniqueValue && rowKeyUnit != rowKey {
+				rowKeyArray = append(rowKeyArray, rowKeyUnit)
+			}
+		}
+	}
+
+	return
+}
+
+func (memoryTable *transportMemoryTable) validate() error {
+	for _, rowKey := range memoryTable.rowKeyArray {
+		for _, fieldSqlName := range memoryTable.table.GetSqlFieldNameArray() {
+			tableField := memoryTable.table.GetFieldBySqlName(fieldSqlName)
+
+			fieldValue, ok := memoryTable.rowMap[rowKey][fieldSqlName]
+			if ok && fieldValue == nil && (tableField.IsNotNull() || tableField.IsPrimaryKey()) {
+				return ErrorTransportMemoryViolatesNotNull
+			}
+		}
+	}
+
+	for _, uniqueName := range memoryTable.table.GetUniqueNameArray() {
+		uniqueValueMap := map[string]bool{}
+
+		for _, rowKey := range memoryTable.rowKeyArray {
+			uniqueValue, ok := helperMemoryUniqueValue(memoryTable.table.GetUniqueArray(uniqueName), memoryTable.rowMap[rowKey])
+			if !ok {
+				continue
+			}
+
+			if uniqueValueMap[uniqueValue] {
+				return ErrorTransportMemoryViolatesUniqueGroup
+			}
+
+			uniqueValueMap[uniqueValue] = true
+		}
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------//
+// TRANSPORT MEMORY
+//--------------------------------------------------------------------------------//
+
+type transportMemory struct {
+	Transport
+	database *Database
+
+	mutex         chan interface{}
+	isOpened      bool
+	tableMap      map[string]*transportMemoryTable
+	txTableBase   map[string]*transportMemoryTable
+	txTableMap    map[string]*transportMemoryTable
+	indexLast     int64
+	changeCount   int64
+	txError       error
+	txIndexLast   int64
+	txChangeCount int64
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportMemory) helperTable(tableMap map[string]*transportMemoryTable, tableName string) (*transportMemoryTable, error) {
+	memoryTable, ok := tableMap[tableName]
+	if !ok {
+		return nil, ErrorTransportMemoryHasUnknownTable
+	}
+
+	return memoryTable.clone(), nil
+}
+
+func (transport *transportMemory) helperRowArray(memoryTable *transportMemoryTable, whereStringArray []string, whereConditionArray []*Condition) (rowKeyArray []string, err error) {
+	if len(whereStringArray) > 0 {
+		err = ErrorTransportMemoryHasUnsupportedWhere
+		return
+	}
+
+	for _, rowKey := range memoryTable.rowKeyArray {
+		var rowMatch bool
+
+		rowMatch, err = helperMemoryMatch(memoryTable.rowMap[rowKey], whereConditionArray)
+		if err != nil {
+			return
+		}
+
+		if rowMatch {
+			rowKeyArray = append(rowKeyArray, rowKey)
+		}
+	}
+
+	return
+}
+
+func (transport *transportMemory) helperExecute(tableMap map[string]*transportMemoryTable, builderRequest Builder) (indexLast int64, changeCount int64, err error) {
+	var (
+		memoryTable     *transportMemoryTable
+		memoryTableName string
+	)
+
+	switch builder := builderRequest.(type) {
+	case *BuilderCreate:
+		if builder.createTable == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		if _, ok := tableMap[*builder.createName]; ok {
+			if !builder.ifNotExists {
+				err = ErrorTransportMemoryHasTableAlready
+			}
+
+			return
+		}
+
+		tableMap[*builder.createName] = &transportMemoryTable{
+			table:       builder.createTable,
+			rowKeyArray: []string{},
+			rowMap:      map[string]transportRow{},
+		}
+
+		return
+	case *BuilderDrop:
+		if builder.dropName == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		if _, ok := tableMap[*builder.dropName]; !ok {
+			if !builder.ifExists {
+				err = ErrorTransportMemoryHasUnknownTable
+			}
+
+			return
+		}
+
+		delete(tableMap, *builder.dropName)
+		return
+	case *BuilderCreateIndex:
+		if builder.createName == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		_, err = transport.helperTable(tableMap, *builder.createName)
+		return
+	case *BuilderDropIndex:
+		if builder.dropName == nil {
+			err = ErrorBuilderMustHaveATable
+		}
+
+		return
+	case *BuilderAlter:
+		if builder.alterName == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		memoryTableName = *builder.alterName
+		memoryTable, err = transport.helperTable(tableMap, memoryTableName)
+		if err != nil {
+			return
+		}
+
+		switch builder.columnAction {
+		case builderAlterActionRenameTo:
+			if _, ok := tableMap[*builder.renameName]; ok {
+				err = ErrorTransportMemoryHasTableAlready
+				return
+			}
+
+			delete(tableMap, *builder.alterName)
+			tableMap[*builder.renameName] = memoryTable
+			return
+		case builderAlterActionAddColumn, builderAlterActionDropColumn, builderAlterActionRenameColumn, builderAlterActionModifyColumn:
+			var tableField *TableField
+
+			if builder.columnAction != builderAlterActionDropColumn && builder.columnAction != builderAlterActionRenameColumn {
+				tableField, err = builder.helperField()
+				if err != nil {
+					return
+				}
+			}
+
+			for _, rowKey := range memoryTable.rowKeyArray {
+				row := transportRow{}
+				for fieldSqlName, fieldValue := range memoryTable.rowMap[rowKey] {
+					row[fieldSqlName] = fieldValue
+				}
+
+				switch builder.columnAction {
+				case builderAlterActionAddColumn:
+					var fieldValue interface{}
+
+					if tableField.ValueDefault() != nil {
+						fieldValue = helperMemoryLiteral(*tableField.ValueDefault())
+					}
+
+					row[tableField.GetSqlName()], err = helperMemoryConvert(builder.alterTable, tableField, fieldValue)
+				case builderAlterActionDropColumn:
+					delete(row, *builder.columnNameFrom)
+				case builderAlterActionRenameColumn:
+					row[*builder.columnNameTo] = row[*builder.columnNameFrom]
+					delete(row, *builder.columnNameFrom)
+				case builderAlterActionModifyColumn:
+					fieldValue := row[*builder.columnNameFrom]
+					delete(row, *builder.columnNameFrom)
+
+					row[tableField.GetSqlName()], err = helperMemoryConvert(builder.alterTable, tableField, fieldValue)
+				}
+
+				if err != nil {
+					return
+				}
+
+				memoryTable.rowMap[rowKey] = row
+			}
+
+			if builder.alterTable != nil {
+				memoryTable.table = builder.alterTable
+			}
+		default:
+			err = ErrorBuilderMustHaveAnAction
+			return
+		}
+	case *BuilderInsert, *BuilderReplace:
+		var (
+			valueTable *Table
+			valueArray []interface{}
+			isReplace  bool
+		)
+
+		switch builder := builderRequest.(type) {
+		case *BuilderInsert:
+			valueTable, valueArray = builder.insertTable, builder.insertValue
+		case *BuilderReplace:
+			valueTable, valueArray, isReplace = builder.replaceTable, builder.replaceValue, true
+		}
+
+		if valueTable == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		if isReplace && valueTable.GetVersion() != nil && len(valueTable.GetPrimaryKeyArray()) == 0 {
+			err = ErrorTableMustHavePrimaryKey
+			return
+		}
+
+		memoryTableName = valueTable.GetSqlName()
+		memoryTable, err = transport.helperTable(tableMap, memoryTableName)
+		if err != nil {
+			return
+		}
+
+		for _, valueUnit := range valueArray {
+			var row transportRow
+
+			row, err = helperRowFromStruct(valueTable, valueUnit, isReplace)
+			if err != nil {
+				return
+			}
+
+			if isReplace && valueTable.GetVersion() != nil {
+				var isFound, isChanged bool
+
+				isFound, isChanged, err = memoryTable.rowVersionReplace(row)
+				if err != nil {
+					return
+				}
+
+				if isChanged {
+					changeCount++
+				}
+
+				if isFound {
+					continue
+				}
+			} else if isReplace {
+				var rowNormalized transportRow
+
+				rowNormalized, err = memoryTable.clone().rowNormalize(row)
+				if err != nil {
+					return
+				}
+
+				for _, rowKey := range memoryTable.rowConflict(rowNormalized) {
+					memoryTable.rowDelete(rowKey)
+				}
+			}
+
+			indexLast, err = memoryTable.rowInsert(row)
+			if err != nil {
+				return
+			}
+
+			changeCount++
+		}
+
+		if versionBuilder, ok := builderRequest.(builderWithVersionCheck); ok {
+			err = versionBuilder.helperVersionCheck(changeCount)
+			if err != nil {
+				return
+			}
+		}
+	case *BuilderUpdate:
+		var (
+			rowKeyArray       []string
+			setFieldNameArray []string
+			setValueArray     []interface{}
+		)
+
+		if builder.updateTable == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		if len(builder.setStringArray) > 0 {
+			err = ErrorTransportMemoryHasUnsupportedWhere
+			return
+		}
+
+		memoryTableName = builder.updateTable.GetSqlName()
+		memoryTable, err = transport.helperTable(tableMap, memoryTableName)
+		if err != nil {
+			return
+		}
+
+		rowKeyArray, err = transport.helperRowArray(memoryTable, builder.whereStringArray, builder.whereConditionArray)
+		if err != nil {
+			return
+		}
+
+		setFieldNameArray, setValueArray, err = builder.helperSetArray()
+		if err != nil {
+			return
+		}
+
+		for _, rowKey := range rowKeyArray {
+			row := transportRow{}
+			for fieldSqlName, fieldValue := range memoryTable.rowMap[rowKey] {
+				row[fieldSqlName] = fieldValue
+			}
+
+			for setIndex, setFieldName := range setFieldNameArray {
+				tableField := memoryTable.table.GetFieldBySqlName(setFieldName)
+				if tableField == nil {
+					err = ErrorTransportMemoryHasUnknownField
+					return
+				}
+
+				row[setFieldName], err = helperMemoryConvert(memoryTable.table, tableField, setValueArray[setIndex])
+				if err != nil {
+					return
+				}
+			}
+
+			err = memoryTable.rowReplace(rowKey, row)
+			if err != nil {
+				return
+			}
+
+			changeCount++
+		}
+
+		err = builder.helperVersionCheck(changeCount)
+		if err != nil {
+			return
+		}
+	case *BuilderDelete:
+		var rowKeyArray []string
+
+		if builder.deleteTable == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		if softDeleteBuilder := builder.helperSoftDelete(); softDeleteBuilder != nil {
+			return transport.helperExecute(tableMap, softDeleteBuilder)
+		}
+
+		memoryTableName = builder.deleteTable.GetSqlName()
+		memoryTable, err = transport.helperTable(tableMap, memoryTableName)
+		if err != nil {
+			return
+		}
+
+		rowKeyArray, err = transport.helperRowArray(memoryTable, builder.whereStringArray, builder.whereConditionArray)
+		if err != nil {
+			return
+		}
+
+		for _, rowKey := range rowKeyArray {
+			memoryTable.rowDelete(rowKey)
+			changeCount++
+		}
+	case *BuilderCopy:
+		var fromTable *transportMemoryTable
+
+		if builder.copyName == nil || builder.fromName == nil {
+			err = ErrorBuilderMustHaveATable
+			return
+		}
+
+		fromTable, err = transport.helperTable(tableMap, *builder.fromName)
+		if err != nil {
+			return
+		}
+
+		memoryTableName = *builder.copyName
+		memoryTable, err = transport.helperTable(tableMap, memoryTableName)
+		if err != nil {
+			return
+		}
+
+		for _, rowKey := range fromTable.rowKeyArray {
+			row := transportRow{}
+
+			for fieldIndex, fromFieldName := range builder.fromFieldNameArray {
+				fieldValue, ok := fromTable.rowMap[rowKey][fromFieldName]
+				if !ok {
+					err = ErrorTransportMemoryHasUnknownField
+					return
+				}
+
+				row[builder.copyFieldNameArray[fieldIndex]] = fieldValue
+			}
+
+			indexLast, err = memoryTable.rowInsert(row)
+			if err != nil {
+				return
+			}
+
+			changeCount++
+		}
+	default:
+		err = ErrorTransportMemoryHasUnsupportedBuilder
+		return
+	}
+
+	err = memoryTable.validate()
+	if err != nil {
+		return
+	}
+
+	tableMap[memoryTableName] = memoryTable
+	return
+}
+
+func (transport *transportMemory) helperQuery(tableMap map[string]*transportMemoryTable, builderRequest BuilderWithResponse) (response interface{}, err error) {
+	var (
+		builder           *BuilderSelect
+		memoryTable       *transportMemoryTable
+		rowKeyArray       []string
+		responseArray     reflect.Value
+		responseUnitTable *Table
+		responseDistinct  = map[string]bool{}
+		ok                bool
+	)
+
+	builder, ok = builderRequest.(*BuilderSelect)
+	if !ok {
+		err = ErrorTransportMemoryHasUnsupportedBuilder
+		return
+	}
+
+	responseUnitTable = builder.GetResponseTable()
+	if responseUnitTable == nil {
+		err = ErrorBuilderMustHaveATable
+		return
+	}
+
+	switch {
+	case len(builder.fromSelectArray) == 0 && len(builder.fromTableArray) == 0:
+		memoryTable, err = transport.helperTable(tableMap, responseUnitTable.GetSqlName())
+	case len(builder.fromSelectArray) == 0 && len(builder.fromTableArray) == 1:
+		memoryTable, err = transport.helperTable(tableMap, builder.fromTableArray[0].GetSqlName())
+	default:
+		err = ErrorTransportMemoryHasUnsupportedBuilder
+	}
+
+	if err != nil {
+		return
+	}
+
+	rowKeyArray, err = transport.helperRowArray(memoryTable, builder.whereStringArray, builder.helperWhereConditionArray())
+	if err != nil {
+		return
+	}
+
+	if len(builder.orderArray) > 0 {
+		err = helperRowOrderCheck(memoryTable.table, builder.orderArray)
+		if err != nil {
+			return
+		}
+
+		sort.SliceStable(rowKeyArray, func(i, j int) bool {
+			return helperRowOrderCompare(memoryTable.rowMap[rowKeyArray[i]], memoryTable.rowMap[rowKeyArray[j]], builder.orderArray) < 0
+		})
+	}
+
+	responseArray = reflect.MakeSlice(reflect.SliceOf(responseUnitTable.GetGoType()), 0, 0)
+
+	for _, rowKey := range rowKeyArray {
+		var (
+			responseUnitStruct     interface{}
+			responseUnitFieldArray []interface{}
+			responseUnitValueArray []interface{}
+		)
+
+		responseUnitStruct, responseUnitFieldArray, err = responseUnitTable.GetStruct(nil)
+		if err != nil {
+			return
+		}
+
+		for fieldIndex, fieldGoName := range responseUnitTable.GetGoFieldNameArray() {
+			tableField := responseUnitTable.GetFieldByGoName(fieldGoName)
+
+			fieldValue, ok := memoryTable.rowMap[rowKey][tableField.GetSqlName()]
+			if !ok {
+				err = ErrorTransportMemoryHasUnknownField
+				return
+			}
+
+			if fieldScanner, ok := tableField.helperScan(responseUnitFieldArray[fieldIndex]).(sql.Scanner); ok {
+				err = fieldScanner.Scan(fieldValue)
+			} else {
+				err = helperMemoryAssign(reflect.ValueOf(responseUnitFieldArray[fieldIndex]).Elem(), fieldValue)
+			}
+			if err != nil {
+				return
+			}
+
+			responseUnitValueArray = append(responseUnitValueArray, fieldValue)
+		}
+
+		if builder.distinct {
+			responseKey := fmt.Sprintf("%#v", responseUnitValueArray)
+			if responseDistinct[responseKey] {
+				continue
+			}
+
+			responseDistinct[responseKey] = true
+		}
+
+		responseArray = reflect.Append(responseArray, reflect.ValueOf(responseUnitStruct).Elem())
+
+		if builder.limit != nil && int64(responseArray.Len()) >= *builder.limit {
+			break
+		}
+	}
+
+	response = responseArray.Interface()
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportMemory) Lock() {
+	transport.mutex <- true
+}
+
+func (transport *transportMemory) LockSqlDb() *sql.DB {
+	transport.mutex <- true
+	return nil
+}
+
+func (transport *transportMemory) LockSqlTx() *sql.Tx {
+	transport.mutex <- true
+	return nil
+}
+
+func (transport *transportMemory) Unlock() {
+	<-transport.mutex
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportMemory) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportMemory) GetDialect() string {
+	return ""
+}
+
+func (transport *transportMemory) Open() error {
+	transport.mutex <- true
+
+	if transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyOpened
+	}
+
+	transport.isOpened = true
+	transport.tableMap = map[string]*transportMemoryTable{}
+	transport.txTableBase = nil
+	transport.txTableMap = nil
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportMemory) Close() error {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	transport.isOpened = false
+	transport.tableMap = nil
+	transport.txTableBase = nil
+	transport.txTableMap = nil
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportMemory) Execute(builderRequest Builder) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	transport.indexLast, transport.changeCount, err = transport.helperExecute(transport.tableMap, builderRequest)
+
+	<-transport.mutex
+	return
+}
+
+func (transport *transportMemory) Query(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return nil, ErrorTransportIsAlreadyClosed
+	}
+
+	response, err = transport.helperQuery(transport.tableMap, builderRequest)
+
+	<-transport.mutex
+	return
+}
+
+func (transport *transportMemory) executeStatus() (int64, int64) {
+	return transport.indexLast, transport.changeCount
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportMemory) TransactionOpen() (*Transaction, error) {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return nil, ErrorTransportIsAlreadyClosed
+	}
+
+	if transport.txTableMap != nil {
+		<-transport.mutex
+		return nil, ErrorTransactionIsAlreadyOpened
+	}
+
+	transport.txTableBase = make(map[string]*transportMemoryTable, len(transport.tableMap))
+	transport.txTableMap = make(map[string]*transportMemoryTable, len(transport.tableMap))
+	for tableName, memoryTable := range transport.tableMap {
+		transport.txTableBase[tableName] = memoryTable
+		transport.txTableMap[tableName] = memoryTable
+	}
+
+	transaction, err := NewTransaction(transport)
+	transport.txError = err
+	transport.txIndexLast = 0
+	transport.txChangeCount = 0
+	<-transport.mutex
+
+	return transaction, err
+}
+
+func (transport *transportMemory) TransactionCommit() error {
+	transport.mutex <- true
+
+	if transport.txTableMap == nil {
+		<-transport.mutex
+		return ErrorTransactionIsAlreadyClosed
+	}
+
+	for tableName, memoryTable := range transport.txTableBase {
+		if _, ok := transport.txTableMap[tableName]; !ok && transport.tableMap[tableName] == memoryTable {
+			delete(transport.tableMap, tableName)
+		}
+	}
+
+	for tableName, memoryTable := range transport.txTableMap {
+		if transport.txTableBase[tableName] != memoryTable {
+			transport.tableMap[tableName] = memoryTable
+		}
+	}
+
+	transport.txTableBase = nil
+	transport.txTableMap = nil
+	<-transport.mutex
+
+	return nil
+}
+
+func (transport *transportMemory) TransactionRollback() error {
+	transport.mutex <- true
+
+	if transport.txTableMap == nil {
+		<-transport.mutex
+		return ErrorTransactionIsAlreadyClosed
+	}
+
+	transport.txTableBase = nil
+	transport.txTableMap = nil
+	<-transport.mutex
+
+	return nil
+}
+
+func (transport *transportMemory) TransactionStatus() (int64, int64, error) {
+	return transport.txIndexLast, transport.txChangeCount, transport.txError
+}
+
+func (transport *transportMemory) TransactionExecute(builderRequest Builder) (transactionError error) {
+	var (
+		indexLast   int64
+		changeCount int64
+	)
+
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	transport.mutex <- true
+
+	if transport.txTableMap == nil {
+		<-transport.mutex
+		return ErrorTransactionIsAlreadyClosed
+	}
+
+	defer func() {
+		transport.txError = transactionError
+		<-transport.mutex
+	}()
+
+	indexLast, changeCount, transactionError = transport.helperExecute(transport.txTableMap, builderRequest)
+	if transactionError != nil {
+		return
+	}
+
+	transport.txIndexLast = indexLast
+	transport.txChangeCount += changeCount
+
+	return
+}
+
+func (transport *transportMemory) TransactionQuery(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	transport.mutex <- true
+
+	if transport.txTableMap == nil {
+		<-transport.mutex
+		return nil, ErrorTransactionIsAlreadyClosed
+	}
+
+	response, err = transport.helperQuery(transport.txTableMap, builderRequest)
+
+	<-transport.mutex
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportMemory() Transport {
+	return &transportMemory{
+		mutex: make(chan interface{}, 1),
+	}
+}
+
+//--------------------------------------------------------------------------------//
+// TOOL
+//--------------------------------------------------------------------------------//
+
+func helperMemoryConvert(table *Table, tableField *TableField, value interface{}) (interface{}, error) {
+	value = helperValueIndirect(value)
+	if value == nil {
+		return nil, nil
+	}
+
+	if tableField == nil {
+		return nil, ErrorTransportMemoryHasUnknownField
+	}
+
+	if tableField.IsJson() || tableField.IsTime() {
+		if _, ok := value.(string); !ok {
+			return nil, ErrorTransportMemoryHasUnsupportedValue
+		}
+
+		return value, nil
+	}
+
+	if tableField.isValuer {
+		return value, nil
+	}
+
+	fieldType := tableField.goField.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	valueReflectValue := reflect.ValueOf(value)
+	if valueReflectValue.Type() == fieldType {
+		return value, nil
+	}
+
+	if (fieldType.Kind() == reflect.String) != (valueReflectValue.Kind() == reflect.String) {
+		return nil, ErrorTransportMemoryHasUnsupportedValue
+	}
+
+	if !valueReflectValue.Type().ConvertibleTo(fieldType) {
+		return nil, ErrorTransportMemoryHasUnsupportedValue
+	}
+
+	return valueReflectValue.Convert(fieldType).Interface(), nil
+}
+
+func helperMemoryAssign(target reflect.Value, value interface{}) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	targetType := target.Type()
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	valueReflectValue := reflect.ValueOf(value)
+	if !valueReflectValue.Type().ConvertibleTo(targetType) {
+		return ErrorTransportMemoryHasUnsupportedValue
+	}
+
+	valueReflectValue = valueReflectValue.Convert(targetType)
+
+	if target.Kind() == reflect.Ptr {
+		targetValue := reflect.New(targetType)
+		targetValue.Elem().Set(valueReflectValue)
+		target.Set(targetValue)
+	} else {
+		target.Set(valueReflectValue)
+	}
+
+	return nil
+}
+
+func helperMemoryLiteral(literal string) interface{} {
+	literal = strings.TrimSpace(literal)
+
+	if strings.EqualFold(literal, "NULL") {
+		return nil
+	}
+
+	if len(literal) >= 2 && (literal[0] == '\'' || literal[0] == '"') && literal[len(literal)-1] == literal[0] {
+		return literal[1 : len(literal)-1]
+	}
+
+	if literalInt, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		return literalInt
+	}
+
+	if literalFloat, err := strconv.ParseFloat(literal, 64); err == nil {
+		return literalFloat
+	}
+
+	return literal
+}
+
+func helperMemoryJsonExtract(value interface{}, path string) (interface{}, error) {
+	var (
+		valueString string
+		valueJson   interface{}
+	)
+
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		valueString = value
+	default:
+		return nil, ErrorTransportMemoryHasUnsupportedValue
+	}
+
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("%w: %q", ErrorConditionHasUnsupportedValue, path)
+	}
+
+	err := json.Unmarshal([]byte(valueString), &valueJson)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorTableFieldHasInvalidJson, err)
+	}
+
+	for pathRest := path[1:]; len(pathRest) > 0; {
+		var pathKey string
+
+		switch pathRest[0] {
+		case '.':
+			pathKey, pathRest = pathRest[1:], ""
+			if pathIndex := strings.IndexAny(pathKey, ".["); pathIndex >= 0 {
+				pathKey, pathRest = pathKey[:pathIndex], pathKey[pathIndex:]
+			}
+
+			valueObject, ok := valueJson.(map[string]interface{})
+			if !ok {
+				return nil, nil
+			}
+
+			valueJson = valueObject[strings.Trim(pathKey, "\"")]
+		case '[':
+			pathIndex := strings.IndexByte(pathRest, ']')
+			if pathIndex < 0 {
+				return nil, fmt.Errorf("%w: %q", ErrorConditionHasUnsupportedValue, path)
+			}
+
+			pathKey, pathRest = pathRest[1:pathIndex], pathRest[pathIndex+1:]
+
+			arrayIndex, err := strconv.Atoi(strings.TrimSpace(pathKey))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrorConditionHasUnsupportedValue, path)
+			}
+
+			valueArray, ok := valueJson.([]interface{})
+			if !ok || arrayIndex < 0 || arrayIndex >= len(valueArray) {
+				return nil, nil
+			}
+
+			valueJson = valueArray[arrayIndex]
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrorConditionHasUnsupportedValue, path)
+		}
+	}
+
+	switch valueJson.(type) {
+	case map[string]interface{}, []interface{}:
+		valueByte, _ := json.Marshal(valueJson)
+		return string(valueByte), nil
+	}
+
+	return valueJson, nil
+}
+
+func helperMemoryMatch(row transportRow, conditionArray []*Condition) (bool, error) {
+	for _, condition := range conditionArray {
+		if condition == nil {
+			return false, ErrorConditionIsNil
+		}
+
+		fieldValue, ok := row[condition.GetFieldName()]
+		if !ok {
+			return false, ErrorTransportMemoryHasUnknownField
+		}
+
+		if condition.GetFieldPath() != nil {
+			var err error
+
+			fieldValue, err = helperMemoryJsonExtract(fieldValue, *condition.GetFieldPath())
+			if err != nil {
+				return false, err
+			}
+		}
+
+		conditionMatch := false
+		conditionValueArray := helperConditionValueArray(condition.GetValueArray())
+
+		switch condition.GetOperator() {
+		case ConditionOperatorIsNull:
+			conditionMatch = fieldValue == nil
+		case ConditionOperatorIsNotNull:
+			conditionMatch = fieldValue != nil
+		case ConditionOperatorIn:
+			for _, conditionValue := range conditionValueArray {
+				conditionValue = helperValueIndirect(conditionValue)
+				if fieldValue != nil && conditionValue != nil && helperValueCompare(fieldValue, conditionValue) == 0 {
+					conditionMatch = true
+					break
+				}
+			}
+		default:
+			if len(conditionValueArray) != 1 {
+				return false, ErrorConditionHasUnsupportedValue
+			}
+
+			conditionValue := helperValueIndirect(conditionValueArray[0])
+			if fieldValue == nil || conditionValue == nil {
+				break
+			}
+
+			conditionCompare := helperValueCompare(fieldValue, conditionValue)
+
+			switch condition.GetOperator() {
+			case ConditionOperatorEqual:
+				conditionMatch = conditionCompare == 0
+			case ConditionOperatorNotEqual:
+				conditionMatch = conditionCompare != 0
+			case ConditionOperatorLess:
+				conditionMatch = conditionCompare < 0
+			case ConditionOperatorLessOrEqual:
+				conditionMatch = conditionCompare <= 0
+			case ConditionOperatorGreater:
+				conditionMatch = conditionCompare > 0
+			case ConditionOperatorGreaterOrEqual:
+				conditionMatch = conditionCompare >= 0
+			default:
+				return false, ErrorConditionHasUnsupportedOperator
+			}
+		}
+
+		if !conditionMatch {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func helperMemoryUniqueValue(uniqueArray []*TableField, row transportRow) (string, bool) {
+	uniqueValueArray := []string{}
+
+	for _, tableField := range uniqueArray {
+		fieldValue := row[tableField.GetSqlName()]
+		if fieldValue == nil {
+			return "", false
+		}
+
+		uniqueValueArray = append(uniqueValueArray, fmt.Sprintf("%v", fieldValue))
+	}
+
+	return strings.Join(uniqueValueArray, "\x00"), true
+}
+
+//--------------------------------------------------------------------------------//
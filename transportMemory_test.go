@@ -0,0 +1,156 @@
+package sqlctrl
+
+import (
+	"testing"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testMemoryItem struct {
+	Id    int64  `sql:"NAME=id | PRIMARY_KEY | AUTO_INCREMENT"`
+	Name  string `sql:"NAME=name"`
+	Count int64  `sql:"NAME=count"`
+}
+
+func testMemoryOpen(t *testing.T) (*Database, *Table) {
+	t.Helper()
+
+	database, err := NewDatabase(NewTransportMemory(), NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("items", testMemoryItem{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table
+}
+
+func testMemoryQuery(t *testing.T, database *Database, builder *BuilderSelect) []testMemoryItem {
+	t.Helper()
+
+	response, err := database.Query(builder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.([]testMemoryItem)
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTransportMemorySmoke(t *testing.T) {
+	database, table := testMemoryOpen(t)
+
+	err := database.Execute(NewBuilderInsert(table).Value(
+		testMemoryItem{Name: "a", Count: 3},
+		testMemoryItem{Name: "b", Count: 1},
+		testMemoryItem{Name: "c", Count: 2},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itemArray := testMemoryQuery(t, database, NewBuilderSelect(table).OrderBy("count").Limit(2))
+	if len(itemArray) != 2 || itemArray[0].Name != "b" || itemArray[1].Name != "c" {
+		t.Errorf("ordered select returned %v, want b, c", itemArray)
+	}
+
+	err = database.Execute(NewBuilderUpdate(table).SetValue("count", 10).WhereCondition(NewConditionEqual("name", "a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderDelete(table).WhereCondition(NewConditionEqual("name", "b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itemArray = testMemoryQuery(t, database, NewBuilderSelect(table).OrderByDesc("count"))
+	if len(itemArray) != 2 || itemArray[0].Name != "a" || itemArray[0].Count != 10 || itemArray[1].Name != "c" {
+		t.Errorf("select after update and delete returned %v, want a(10), c", itemArray)
+	}
+}
+
+func TestTransportMemoryTransaction(t *testing.T) {
+	database, table := testMemoryOpen(t)
+
+	transaction, err := database.TransactionOpen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.ExecuteInsertValue(table, testMemoryItem{Name: "rolled back"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.Rollback()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction, err = database.TransactionOpen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.ExecuteInsertValue(table, testMemoryItem{Name: "committed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itemArray := testMemoryQuery(t, database, NewBuilderSelect(table))
+	if len(itemArray) != 1 || itemArray[0].Name != "committed" {
+		t.Errorf("select after the transactions returned %v, want only the committed item", itemArray)
+	}
+}
+
+func TestTransportMemoryTransactionIsolation(t *testing.T) {
+	database, table := testMemoryOpen(t)
+
+	transaction, err := database.TransactionOpen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.ExecuteInsertValue(table, testMemoryItem{Name: "pending"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if itemArray := testMemoryQuery(t, database, NewBuilderSelect(table)); len(itemArray) != 0 {
+		t.Errorf("select outside the transaction returned %v, want no uncommitted items", itemArray)
+	}
+
+	response, err := transaction.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if itemArray := response.([]testMemoryItem); len(itemArray) != 1 {
+		t.Errorf("select inside the transaction returned %v, want the pending item", itemArray)
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if itemArray := testMemoryQuery(t, database, NewBuilderSelect(table)); len(itemArray) != 1 || itemArray[0].Name != "pending" {
+		t.Errorf("select after the commit returned %v, want the committed item", itemArray)
+	}
+}
+
+//--------------------------------------------------------------------------------//
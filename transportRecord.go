@@ -0,0 +1,762 @@
+package sqlctrl
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+const (
+	transportRecordActionHeader              = "header"
+	transportRecordActionExecute             = "execute"
+	transportRecordActionQuery               = "query"
+	transportRecordActionTransactionOpen     = "transaction_open"
+	transportRecordActionTransactionCommit   = "transaction_commit"
+	transportRecordActionTransactionRollback = "transaction_rollback"
+	transportRecordActionTransactionExecute  = "transaction_execute"
+	transportRecordActionTransactionQuery    = "transaction_query"
+)
+
+type transportRecordEntry struct {
+	Action      string                       `json:"action"`
+	Dialect     string                       `json:"dialect,omitempty"`
+	Sql         string                       `json:"sql,omitempty"`
+	Option      json.RawMessage              `json:"option,omitempty"`
+	RowArray    []map[string]json.RawMessage `json:"row_array,omitempty"`
+	IndexLast   int64                        `json:"index_last,omitempty"`
+	ChangeCount int64                        `json:"change_count,omitempty"`
+	Error       string                       `json:"error,omitempty"`
+	ErrorId     string                       `json:"error_id,omitempty"`
+}
+
+var transportRecordErrorArray = []error{
+	ErrorBuilderIsNil,
+	ErrorBuilderWithoutResponse,
+	ErrorBuilderHasUnsupportedDialect,
+	ErrorTransportIsAlreadyOpened,
+	ErrorTransportIsAlreadyClosed,
+	ErrorTransportMemoryHasUnknownTable,
+	ErrorTransportMemoryHasUnknownField,
+	ErrorTransportMemoryHasTableAlready,
+	ErrorTransportMemoryHasUnsupportedBuilder,
+	ErrorTransportMemoryHasUnsupportedWhere,
+	ErrorTransportMemoryHasUnsupportedValue,
+	ErrorTransportMemoryViolatesPrimaryKey,
+	ErrorTransportMemoryViolatesNotNull,
+	ErrorTransportMemoryViolatesUniqueGroup,
+	ErrorTransactionIsAlreadyOpened,
+	ErrorTransactionIsAlreadyClosed,
+	ErrorTransactionViolatesReference,
+	ErrorOptimisticLockConflict,
+}
+
+//--------------------------------------------------------------------------------//
+
+func helperRecordBuild(entry *transportRecordEntry, builderRequest Builder) (err error) {
+	var (
+		builderString string
+		builderOption []interface{}
+	)
+
+	builderString, builderOption, err = builderRequest.Build()
+	if err != nil {
+		return
+	}
+
+	entry.Sql = builderString
+
+	if len(builderOption) > 0 {
+		entry.Option, err = json.Marshal(builderOption)
+	}
+
+	return
+}
+
+func helperRecordError(entry *transportRecordEntry, err error) {
+	if err == nil {
+		return
+	}
+
+	entry.Error = err.Error()
+
+	for _, errorSentinel := range transportRecordErrorArray {
+		if errors.Is(err, errorSentinel) {
+			entry.ErrorId = errorSentinel.Error()
+			return
+		}
+	}
+}
+
+func helperReplayError(entry *transportRecordEntry) error {
+	if len(entry.Error) == 0 {
+		return nil
+	}
+
+	for _, errorSentinel := range transportRecordErrorArray {
+		if entry.ErrorId != errorSentinel.Error() {
+			continue
+		}
+
+		if strings.HasPrefix(entry.Error, entry.ErrorId) {
+			return fmt.Errorf("%w%s", errorSentinel, entry.Error[len(entry.ErrorId):])
+		}
+
+		return fmt.Errorf("%w: %s", errorSentinel, entry.Error)
+	}
+
+	return errors.New(entry.Error)
+}
+
+func helperRecordRowArray(entry *transportRecordEntry, responseUnitTable *Table, response interface{}) (err error) {
+	responseArray := reflect.ValueOf(response)
+
+	entry.RowArray = []map[string]json.RawMessage{}
+	for responseIndex := 0; responseIndex < responseArray.Len(); responseIndex++ {
+		responseUnit := responseArray.Index(responseIndex)
+		row := map[string]json.RawMessage{}
+
+		for _, fieldGoName := range responseUnitTable.GetGoFieldNameArray() {
+			tableField := responseUnitTable.GetFieldByGoName(fieldGoName)
+
+			row[tableField.GetSqlName()], err = json.Marshal(tableField.helperValue(responseUnit).Interface())
+			if err != nil {
+				return
+			}
+		}
+
+		entry.RowArray = append(entry.RowArray, row)
+	}
+
+	return
+}
+
+func helperReplayRowArray(entry *transportRecordEntry, responseUnitTable *Table) (response interface{}, err error) {
+	var (
+		responseArray          reflect.Value
+		responseUnitStruct     interface{}
+		responseUnitFieldArray []interface{}
+	)
+
+	responseArray = reflect.MakeSlice(reflect.SliceOf(responseUnitTable.GetGoType()), 0, 0)
+
+	for _, row := range entry.RowArray {
+		responseUnitStruct, responseUnitFieldArray, err = responseUnitTable.GetStruct(nil)
+		if err != nil {
+			return
+		}
+
+		for fieldIndex, fieldGoName := range responseUnitTable.GetGoFieldNameArray() {
+			tableField := responseUnitTable.GetFieldByGoName(fieldGoName)
+
+			fieldValue, ok := row[tableField.GetSqlName()]
+			if !ok {
+				err = fmt.Errorf("%w: field %s is missing from the recorded row", ErrorTransportReplayHasDrift, tableField.GetSqlName())
+				return
+			}
+
+			err = json.Unmarshal(fieldValue, responseUnitFieldArray[fieldIndex])
+			if err != nil {
+				return
+			}
+		}
+
+		responseArray = reflect.Append(responseArray, reflect.ValueOf(responseUnitStruct).Elem())
+	}
+
+	response = responseArray.Interface()
+	return
+}
+
+//--------------------------------------------------------------------------------//
+// TRANSPORT RECORD
+//--------------------------------------------------------------------------------//
+
+type transportRecord struct {
+	Transport
+	database *Database
+
+	mutex       chan interface{}
+	transport   Transport
+	fixturePath string
+	fixtureFile *os.File
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportRecord) helperWrite(entry *transportRecordEntry) (err error) {
+	var entryJson []byte
+
+	entryJson, err = json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	if transport.fixtureFile == nil {
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	_, err = transport.fixtureFile.Write(append(entryJson, '\n'))
+	return
+}
+
+func (transport *transportRecord) helperExecute(action string, builderRequest Builder, builderExecute func(Builder) error) (err error) {
+	entry := &transportRecordEntry{
+		Action: action,
+	}
+
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	err = builderExecute(builderRequest)
+	helperRecordError(entry, err)
+
+	if action == transportRecordActionTransactionExecute {
+		entry.IndexLast, entry.ChangeCount, _ = transport.transport.TransactionStatus()
+	} else {
+		entry.IndexLast, entry.ChangeCount = helperExecuteStatus(transport.transport)
+	}
+
+	buildError := helperRecordBuild(entry, builderRequest)
+	if buildError != nil && err == nil {
+		return buildError
+	}
+
+	writeError := transport.helperWrite(entry)
+	if writeError != nil && err == nil {
+		err = writeError
+	}
+
+	return
+}
+
+func (transport *transportRecord) helperQuery(action string, builderRequest BuilderWithResponse, builderQuery func(BuilderWithResponse) (interface{}, error)) (response interface{}, err error) {
+	entry := &transportRecordEntry{
+		Action: action,
+	}
+
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	response, err = builderQuery(builderRequest)
+	helperRecordError(entry, err)
+
+	if err == nil {
+		err = helperRecordRowArray(entry, builderRequest.GetResponseTable(), response)
+		if err != nil {
+			return
+		}
+	}
+
+	buildError := helperRecordBuild(entry, builderRequest)
+	if buildError != nil && err == nil {
+		return nil, buildError
+	}
+
+	writeError := transport.helperWrite(entry)
+	if writeError != nil && err == nil {
+		err = writeError
+	}
+
+	return
+}
+
+func (transport *transportRecord) helperAction(action string, actionError error) error {
+	entry := &transportRecordEntry{
+		Action: action,
+	}
+
+	helperRecordError(entry, actionError)
+
+	writeError := transport.helperWrite(entry)
+	if writeError != nil && actionError == nil {
+		return writeError
+	}
+
+	return actionError
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportRecord) Lock() {
+	transport.transport.Lock()
+}
+
+func (transport *transportRecord) LockSqlDb() *sql.DB {
+	return transport.transport.LockSqlDb()
+}
+
+func (transport *transportRecord) LockSqlTx() *sql.Tx {
+	return transport.transport.LockSqlTx()
+}
+
+func (transport *transportRecord) Unlock() {
+	transport.transport.Unlock()
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportRecord) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if transport.fixtureFile == nil {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportRecord) GetDialect() string {
+	return transport.transport.GetDialect()
+}
+
+func (transport *transportRecord) Open() (err error) {
+	transport.mutex <- true
+
+	if transport.fixtureFile != nil {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyOpened
+	}
+
+	if transport.transport == nil {
+		<-transport.mutex
+		return ErrorTransportIsNil
+	}
+
+	err = transport.transport.Open()
+	if err != nil {
+		<-transport.mutex
+		return
+	}
+
+	transport.fixtureFile, err = os.Create(transport.fixturePath)
+	if err != nil {
+		transport.transport.Close()
+
+		<-transport.mutex
+		return
+	}
+
+	<-transport.mutex
+
+	return transport.helperWrite(&transportRecordEntry{
+		Action:  transportRecordActionHeader,
+		Dialect: transport.transport.GetDialect(),
+	})
+}
+
+func (transport *transportRecord) Close() (err error) {
+	transport.mutex <- true
+
+	if transport.fixtureFile == nil {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	err = transport.fixtureFile.Close()
+	transport.fixtureFile = nil
+
+	transportError := transport.transport.Close()
+	if transportError != nil && err == nil {
+		err = transportError
+	}
+
+	<-transport.mutex
+	return
+}
+
+func (transport *transportRecord) Execute(builderRequest Builder) error {
+	return transport.helperExecute(transportRecordActionExecute, builderRequest, transport.transport.Execute)
+}
+
+func (transport *transportRecord) Query(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.helperQuery(transportRecordActionQuery, builderRequest, transport.transport.Query)
+}
+
+func (transport *transportRecord) executeStatus() (int64, int64) {
+	return helperExecuteStatus(transport.transport)
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportRecord) TransactionOpen() (*Transaction, error) {
+	_, err := transport.transport.TransactionOpen()
+
+	err = transport.helperAction(transportRecordActionTransactionOpen, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransaction(transport)
+}
+
+func (transport *transportRecord) TransactionCommit() error {
+	return transport.helperAction(transportRecordActionTransactionCommit, transport.transport.TransactionCommit())
+}
+
+func (transport *transportRecord) TransactionRollback() error {
+	return transport.helperAction(transportRecordActionTransactionRollback, transport.transport.TransactionRollback())
+}
+
+func (transport *transportRecord) TransactionStatus() (int64, int64, error) {
+	return transport.transport.TransactionStatus()
+}
+
+func (transport *transportRecord) TransactionExecute(builderRequest Builder) error {
+	return transport.helperExecute(transportRecordActionTransactionExecute, builderRequest, transport.transport.TransactionExecute)
+}
+
+func (transport *transportRecord) TransactionQuery(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.helperQuery(transportRecordActionTransactionQuery, builderRequest, transport.transport.TransactionQuery)
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportRecord(transport Transport, fixturePath string) Transport {
+	return &transportRecord{
+		mutex:       make(chan interface{}, 1),
+		transport:   transport,
+		fixturePath: fixturePath,
+	}
+}
+
+//--------------------------------------------------------------------------------//
+// TRANSPORT REPLAY
+//--------------------------------------------------------------------------------//
+
+type transportReplay struct {
+	Transport
+	database *Database
+
+	mutex         chan interface{}
+	isOpened      bool
+	fixturePath   string
+	entryArray    []*transportRecordEntry
+	entryIndex    int
+	sqlDialect    string
+	indexLast     int64
+	changeCount   int64
+	txError       error
+	txIndexLast   int64
+	txChangeCount int64
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportReplay) helperNext(action string, builderRequest Builder) (entry *transportRecordEntry, err error) {
+	var expectEntry = &transportRecordEntry{
+		Action: action,
+	}
+
+	if builderRequest != nil {
+		switch v := builderRequest.(type) {
+		case BuilderWithDialect:
+			v.SetDialect(transport.sqlDialect)
+		}
+
+		err = helperRecordBuild(expectEntry, builderRequest)
+		if err != nil {
+			return
+		}
+	}
+
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	if !transport.isOpened {
+		err = ErrorTransportIsAlreadyClosed
+		return
+	}
+
+	if transport.entryIndex >= len(transport.entryArray) {
+		err = fmt.Errorf("%w: unexpected %s %s after the end of the recording", ErrorTransportReplayHasDrift, expectEntry.Action, expectEntry.Sql)
+		return
+	}
+
+	entry = transport.entryArray[transport.entryIndex]
+
+	if entry.Action != expectEntry.Action || entry.Sql != expectEntry.Sql || !bytes.Equal(entry.Option, expectEntry.Option) {
+		err = fmt.Errorf("%w: entry %d expected %s %s %s, got %s %s %s", ErrorTransportReplayHasDrift, transport.entryIndex,
+			entry.Action, entry.Sql, string(entry.Option), expectEntry.Action, expectEntry.Sql, string(expectEntry.Option))
+		return
+	}
+
+	transport.entryIndex++
+
+	if entry.Action == transportRecordActionTransactionOpen {
+		transport.txIndexLast, transport.txChangeCount, transport.txError = 0, 0, nil
+	}
+
+	err = helperReplayError(entry)
+
+	switch entry.Action {
+	case transportRecordActionExecute:
+		transport.indexLast, transport.changeCount = entry.IndexLast, entry.ChangeCount
+
+		if versionBuilder, ok := builderRequest.(builderWithVersionCheck); ok && err == nil {
+			err = versionBuilder.helperVersionCheck(entry.ChangeCount)
+		}
+	case transportRecordActionTransactionExecute:
+		if versionBuilder, ok := builderRequest.(builderWithVersionCheck); ok && err == nil {
+			err = versionBuilder.helperVersionCheck(entry.ChangeCount - transport.txChangeCount)
+		}
+
+		transport.txIndexLast, transport.txChangeCount = entry.IndexLast, entry.ChangeCount
+	}
+
+	if entry.Action == transportRecordActionTransactionExecute {
+		transport.txError = err
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportReplay) Lock() {
+	transport.mutex <- true
+}
+
+func (transport *transportReplay) LockSqlDb() *sql.DB {
+	transport.mutex <- true
+	return nil
+}
+
+func (transport *transportReplay) LockSqlTx() *sql.Tx {
+	transport.mutex <- true
+	return nil
+}
+
+func (transport *transportReplay) Unlock() {
+	<-transport.mutex
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportReplay) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportReplay) GetDialect() string {
+	return transport.sqlDialect
+}
+
+func (transport *transportReplay) Open() (err error) {
+	var (
+		fixtureFile *os.File
+		entryArray  []*transportRecordEntry
+	)
+
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	if transport.isOpened {
+		return ErrorTransportIsAlreadyOpened
+	}
+
+	fixtureFile, err = os.Open(transport.fixturePath)
+	if err != nil {
+		return
+	}
+	defer fixtureFile.Close()
+
+	fixtureScanner := bufio.NewScanner(fixtureFile)
+	fixtureScanner.Buffer(nil, 64*1024*1024)
+
+	for fixtureScanner.Scan() {
+		if len(bytes.TrimSpace(fixtureScanner.Bytes())) == 0 {
+			continue
+		}
+
+		entry := &transportRecordEntry{}
+
+		err = json.Unmarshal(fixtureScanner.Bytes(), entry)
+		if err != nil {
+			return
+		}
+
+		entryArray = append(entryArray, entry)
+	}
+
+	err = fixtureScanner.Err()
+	if err != nil {
+		return
+	}
+
+	if len(entryArray) == 0 || entryArray[0].Action != transportRecordActionHeader {
+		return ErrorTransportReplayHasUnsupportedFixture
+	}
+
+	transport.isOpened = true
+	transport.sqlDialect = entryArray[0].Dialect
+	transport.entryArray = entryArray[1:]
+	transport.entryIndex = 0
+
+	return
+}
+
+func (transport *transportReplay) Close() error {
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	if !transport.isOpened {
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	transport.isOpened = false
+
+	if transport.entryIndex < len(transport.entryArray) {
+		entry := transport.entryArray[transport.entryIndex]
+		return fmt.Errorf("%w: %d entries were not replayed, next is %s %s", ErrorTransportReplayHasDrift, len(transport.entryArray)-transport.entryIndex, entry.Action, entry.Sql)
+	}
+
+	return nil
+}
+
+func (transport *transportReplay) Execute(builderRequest Builder) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	_, err = transport.helperNext(transportRecordActionExecute, builderRequest)
+	return
+}
+
+func (transport *transportReplay) Query(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	var entry *transportRecordEntry
+
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	entry, err = transport.helperNext(transportRecordActionQuery, builderRequest)
+	if err != nil {
+		return
+	}
+
+	return helperReplayRowArray(entry, builderRequest.GetResponseTable())
+}
+
+func (transport *transportReplay) executeStatus() (int64, int64) {
+	return transport.indexLast, transport.changeCount
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportReplay) TransactionOpen() (*Transaction, error) {
+	_, err := transport.helperNext(transportRecordActionTransactionOpen, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransaction(transport)
+}
+
+func (transport *transportReplay) TransactionCommit() (err error) {
+	_, err = transport.helperNext(transportRecordActionTransactionCommit, nil)
+	return
+}
+
+func (transport *transportReplay) TransactionRollback() (err error) {
+	_, err = transport.helperNext(transportRecordActionTransactionRollback, nil)
+	return
+}
+
+func (transport *transportReplay) TransactionStatus() (int64, int64, error) {
+	return transport.txIndexLast, transport.txChangeCount, transport.txError
+}
+
+func (transport *transportReplay) TransactionExecute(builderRequest Builder) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	_, err = transport.helperNext(transportRecordActionTransactionExecute, builderRequest)
+	return
+}
+
+func (transport *transportReplay) TransactionQuery(builderRequest BuilderWithResponse) (response interface{}, err error) {
+	var entry *transportRecordEntry
+
+	if builderRequest == nil {
+		return nil, ErrorBuilderIsNil
+	}
+
+	entry, err = transport.helperNext(transportRecordActionTransactionQuery, builderRequest)
+	if err != nil {
+		return
+	}
+
+	return helperReplayRowArray(entry, builderRequest.GetResponseTable())
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportReplay(fixturePath string) Transport {
+	return &transportReplay{
+		mutex:       make(chan interface{}, 1),
+		fixturePath: fixturePath,
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,75 @@
+package sqlctrl
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testRecordAccount struct {
+	Id      int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Version int64  `sql:"NAME=version | VERSION"`
+	Owner   string `sql:"NAME=owner"`
+}
+
+func testRecordRun(t *testing.T, transport Transport) (accountArray []testRecordAccount, conflictError error) {
+	t.Helper()
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer database.Close()
+
+	table, err := database.RegisterTable("accounts", testRecordAccount{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testRecordAccount{Id: 1, Owner: "a"}, testRecordAccount{Id: 2, Owner: "b"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Execute(NewBuilderUpdate(table).Value(testRecordAccount{Id: 1, Version: 0, Owner: "c"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflictError = database.Execute(NewBuilderUpdate(table).Value(testRecordAccount{Id: 1, Version: 0, Owner: "d"}))
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.([]testRecordAccount), conflictError
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTransportRecordReplaySmoke(t *testing.T) {
+	fixturePath := filepath.Join(t.TempDir(), "fixture.jsonl")
+
+	recordArray, recordError := testRecordRun(t, NewTransportRecord(NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "record.db")), fixturePath))
+	if !errors.Is(recordError, ErrorOptimisticLockConflict) {
+		t.Errorf("recorded stale update returned %v, want an optimistic lock conflict", recordError)
+	}
+
+	replayArray, replayError := testRecordRun(t, NewTransportReplay(fixturePath))
+	if !errors.Is(replayError, ErrorOptimisticLockConflict) {
+		t.Errorf("replayed stale update returned %v, want an optimistic lock conflict", replayError)
+	}
+
+	if !reflect.DeepEqual(recordArray, replayArray) {
+		t.Errorf("replay returned %v, recording returned %v", replayArray, recordArray)
+	}
+}
+
+//--------------------------------------------------------------------------------//
@@ -0,0 +1,404 @@
+package sqlctrl
+
+import (
+	"database/sql"
+	"time"
+)
+
+//--------------------------------------------------------------------------------//
+
+type TransportReplicatedStrategy int
+
+const (
+	TransportReplicatedRoundRobin TransportReplicatedStrategy = iota
+	TransportReplicatedLeastLatency
+)
+
+//--------------------------------------------------------------------------------//
+
+type TransportReplicated struct {
+	Transport
+	database *Database
+
+	mutex          chan interface{}
+	isOpened       bool
+	primary        Transport
+	replicaArray   []Transport
+	replicaLatency []time.Duration
+	replicaNext    int
+	strategy       TransportReplicatedStrategy
+	stickyWindow   time.Duration
+	sticky         *transportReplicatedSticky
+}
+
+type TransportReplicatedSession struct {
+	*TransportReplicated
+
+	sticky *transportReplicatedSticky
+}
+
+type transportReplicatedSticky struct {
+	mutex       chan interface{}
+	stickyUntil time.Time
+}
+
+//--------------------------------------------------------------------------------//
+
+func (sticky *transportReplicatedSticky) touch(stickyWindow time.Duration) {
+	if stickyWindow <= 0 {
+		return
+	}
+
+	sticky.mutex <- true
+	sticky.stickyUntil = time.Now().Add(stickyWindow)
+	<-sticky.mutex
+}
+
+func (sticky *transportReplicatedSticky) isActive() bool {
+	sticky.mutex <- true
+	defer func() {
+		<-sticky.mutex
+	}()
+
+	return time.Now().Before(sticky.stickyUntil)
+}
+
+func newTransportReplicatedSticky() *transportReplicatedSticky {
+	return &transportReplicatedSticky{
+		mutex:       make(chan interface{}, 1),
+		stickyUntil: time.Time{},
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportReplicated) Strategy(strategy TransportReplicatedStrategy) *TransportReplicated {
+	transport.mutex <- true
+	transport.strategy = strategy
+	<-transport.mutex
+
+	return transport
+}
+
+func (transport *TransportReplicated) StickyWindow(stickyWindow time.Duration) *TransportReplicated {
+	transport.mutex <- true
+	transport.stickyWindow = stickyWindow
+	<-transport.mutex
+
+	return transport
+}
+
+//--------------------------------------------------------------------------------//
+
+// Session returns a handle whose reads stick to the primary for the sticky
+// window after its own writes. The transport itself keeps one such window for
+// the Database it is registered with, so a Database reads its own writes while
+// sessions and other databases stay on the replicas.
+func (transport *TransportReplicated) Session() *TransportReplicatedSession {
+	return &TransportReplicatedSession{
+		TransportReplicated: transport,
+
+		sticky: newTransportReplicatedSticky(),
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportReplicated) helperReplicaSelect() (replicaIndex int, replica Transport) {
+	transport.mutex <- true
+	defer func() {
+		<-transport.mutex
+	}()
+
+	if len(transport.replicaArray) == 0 {
+		return -1, transport.primary
+	}
+
+	switch transport.strategy {
+	case TransportReplicatedLeastLatency:
+		replicaIndex = 0
+		for latencyIndex, latencyValue := range transport.replicaLatency {
+			if latencyValue < transport.replicaLatency[replicaIndex] {
+				replicaIndex = latencyIndex
+			}
+		}
+	default:
+		replicaIndex = transport.replicaNext % len(transport.replicaArray)
+		transport.replicaNext = replicaIndex + 1
+	}
+
+	return replicaIndex, transport.replicaArray[replicaIndex]
+}
+
+func (transport *TransportReplicated) helperReplicaLatency(replicaIndex int, replicaLatency time.Duration) {
+	if replicaIndex < 0 {
+		return
+	}
+
+	transport.mutex <- true
+
+	if transport.replicaLatency[replicaIndex] == 0 {
+		transport.replicaLatency[replicaIndex] = replicaLatency
+	} else {
+		transport.replicaLatency[replicaIndex] = (transport.replicaLatency[replicaIndex]*7 + replicaLatency) / 8
+	}
+
+	<-transport.mutex
+}
+
+func (transport *TransportReplicated) helperStickyTouch(sticky *transportReplicatedSticky) {
+	transport.mutex <- true
+	stickyWindow := transport.stickyWindow
+	<-transport.mutex
+
+	sticky.touch(stickyWindow)
+}
+
+func (transport *TransportReplicated) helperExecute(sticky *transportReplicatedSticky, builderRequest Builder) (err error) {
+	err = transport.primary.Execute(builderRequest)
+	if err != nil {
+		return
+	}
+
+	transport.helperStickyTouch(sticky)
+	return
+}
+
+func (transport *TransportReplicated) helperCommit(sticky *transportReplicatedSticky) (err error) {
+	err = transport.primary.TransactionCommit()
+	if err != nil {
+		return
+	}
+
+	transport.helperStickyTouch(sticky)
+	return
+}
+
+func (transport *TransportReplicated) helperQuery(sticky *transportReplicatedSticky, builderRequest BuilderWithResponse) (response interface{}, err error) {
+	if sticky.isActive() {
+		return transport.primary.Query(builderRequest)
+	}
+
+	replicaIndex, replica := transport.helperReplicaSelect()
+
+	queryStart := time.Now()
+	response, err = replica.Query(builderRequest)
+	transport.helperReplicaLatency(replicaIndex, time.Since(queryStart))
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportReplicated) Lock() {
+	transport.primary.Lock()
+}
+
+func (transport *TransportReplicated) LockSqlDb() *sql.DB {
+	return transport.primary.LockSqlDb()
+}
+
+func (transport *TransportReplicated) LockSqlTx() *sql.Tx {
+	return transport.primary.LockSqlTx()
+}
+
+func (transport *TransportReplicated) Unlock() {
+	transport.primary.Unlock()
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportReplicated) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		for _, subTransport := range append([]Transport{transport.primary}, transport.replicaArray...) {
+			database.Transport = nil
+
+			err := subTransport.TransportRegister(database)
+			if err != nil {
+				database.Transport = nil
+				<-transport.mutex
+				return err
+			}
+		}
+
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *TransportReplicated) GetDialect() string {
+	return transport.primary.GetDialect()
+}
+
+func (transport *TransportReplicated) Open() (err error) {
+	transport.mutex <- true
+
+	if transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyOpened
+	}
+
+	if transport.primary == nil {
+		<-transport.mutex
+		return ErrorTransportIsNil
+	}
+
+	for _, replica := range transport.replicaArray {
+		if replica == nil {
+			<-transport.mutex
+			return ErrorTransportIsNil
+		}
+	}
+
+	err = transport.primary.Open()
+	if err != nil {
+		<-transport.mutex
+		return
+	}
+
+	for replicaIndex, replica := range transport.replicaArray {
+		err = replica.Open()
+		if err != nil {
+			for _, replicaOpened := range transport.replicaArray[:replicaIndex] {
+				replicaOpened.Close()
+			}
+			transport.primary.Close()
+
+			<-transport.mutex
+			return
+		}
+	}
+
+	transport.isOpened = true
+
+	<-transport.mutex
+	return
+}
+
+func (transport *TransportReplicated) Close() (err error) {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	for _, replica := range transport.replicaArray {
+		replicaError := replica.Close()
+		if replicaError != nil && err == nil {
+			err = replicaError
+		}
+	}
+
+	primaryError := transport.primary.Close()
+	if primaryError != nil && err == nil {
+		err = primaryError
+	}
+
+	transport.isOpened = false
+
+	<-transport.mutex
+	return
+}
+
+func (transport *TransportReplicated) Execute(builderRequest Builder) error {
+	return transport.helperExecute(transport.sticky, builderRequest)
+}
+
+func (transport *TransportReplicated) Query(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.helperQuery(transport.sticky, builderRequest)
+}
+
+func (transport *TransportReplicated) executeStatus() (int64, int64) {
+	return helperExecuteStatus(transport.primary)
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *TransportReplicated) TransactionOpen() (*Transaction, error) {
+	_, err := transport.primary.TransactionOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransaction(transport)
+}
+
+func (transport *TransportReplicated) TransactionCommit() error {
+	return transport.helperCommit(transport.sticky)
+}
+
+func (transport *TransportReplicated) TransactionRollback() error {
+	return transport.primary.TransactionRollback()
+}
+
+func (transport *TransportReplicated) TransactionStatus() (int64, int64, error) {
+	return transport.primary.TransactionStatus()
+}
+
+func (transport *TransportReplicated) TransactionExecute(builderRequest Builder) error {
+	return transport.primary.TransactionExecute(builderRequest)
+}
+
+func (transport *TransportReplicated) TransactionQuery(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.primary.TransactionQuery(builderRequest)
+}
+
+//--------------------------------------------------------------------------------//
+
+func (session *TransportReplicatedSession) Execute(builderRequest Builder) error {
+	return session.helperExecute(session.sticky, builderRequest)
+}
+
+func (session *TransportReplicatedSession) Query(builderRequest BuilderWithResponse) (interface{}, error) {
+	return session.helperQuery(session.sticky, builderRequest)
+}
+
+func (session *TransportReplicatedSession) TransactionOpen() (*Transaction, error) {
+	_, err := session.primary.TransactionOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransaction(session)
+}
+
+func (session *TransportReplicatedSession) TransactionCommit() error {
+	return session.helperCommit(session.sticky)
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportReplicated(primary Transport, replicaArray ...Transport) *TransportReplicated {
+	return &TransportReplicated{
+		mutex:          make(chan interface{}, 1),
+		primary:        primary,
+		replicaArray:   replicaArray,
+		replicaLatency: make([]time.Duration, len(replicaArray)),
+		strategy:       TransportReplicatedRoundRobin,
+		stickyWindow:   0,
+		sticky:         newTransportReplicatedSticky(),
+	}
+}
+
+//--------------------------------------------------------------------------------//
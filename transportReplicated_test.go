@@ -0,0 +1,156 @@
+package sqlctrl
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testReplicatedNote struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Text string `sql:"NAME=text"`
+}
+
+func testReplicatedOpen(t *testing.T, stickyWindow time.Duration) (*Database, *Table) {
+	t.Helper()
+
+	primary := NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "primary.db"))
+	replica := NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "replica.db"))
+
+	database, err := NewDatabase(NewTransportReplicated(primary, replica).StickyWindow(stickyWindow), NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("notes", testReplicatedNote{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replica.Execute(NewBuilderCreate(table).IfNotExists(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction, err := database.TransactionOpen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.ExecuteInsertValue(table, testReplicatedNote{Id: 1, Text: "committed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table
+}
+
+func testReplicatedNoteCount(t *testing.T, database *Database, table *Table) int {
+	t.Helper()
+
+	response, err := database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return len(response.([]testReplicatedNote))
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTransportReplicatedSmoke(t *testing.T) {
+	primary := NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "primary.db"))
+	replica := NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "replica.db"))
+	transport := NewTransportReplicated(primary, replica).StickyWindow(time.Minute)
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	if primary.(*transportSimple).database != database || replica.(*transportSimple).database != database {
+		t.Error("primary and replica are not registered with the database")
+	}
+
+	table, err := database.RegisterTable("notes", testReplicatedNote{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replica.Execute(NewBuilderCreate(table).IfNotExists(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session := transport.Session()
+
+	err = session.Execute(NewBuilderInsert(table).Value(testReplicatedNote{Id: 1, Text: "written"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := session.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if noteArray := response.([]testReplicatedNote); len(noteArray) != 1 {
+		t.Errorf("session read after its write returned %v, want the primary row", noteArray)
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(testReplicatedNote{Id: 2, Text: "written"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err = database.Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if noteArray := response.([]testReplicatedNote); len(noteArray) != 2 {
+		t.Errorf("database read after its write returned %v, want the primary rows", noteArray)
+	}
+
+	response, err = transport.Session().Query(NewBuilderSelect(table))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if noteArray := response.([]testReplicatedNote); len(noteArray) != 0 {
+		t.Errorf("read from another session returned %v, want the empty replica", noteArray)
+	}
+}
+
+func TestTransportReplicatedDatabaseSticky(t *testing.T) {
+	database, table := testReplicatedOpen(t, time.Minute)
+
+	if noteCount := testReplicatedNoteCount(t, database, table); noteCount != 1 {
+		t.Errorf("database read after its commit returned %d notes, want the primary row", noteCount)
+	}
+
+	database, table = testReplicatedOpen(t, 0)
+
+	if noteCount := testReplicatedNoteCount(t, database, table); noteCount != 0 {
+		t.Errorf("database read without a sticky window returned %d notes, want the empty replica", noteCount)
+	}
+}
+
+//--------------------------------------------------------------------------------//
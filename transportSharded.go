@@ -0,0 +1,534 @@
+package sqlctrl
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+)
+
+//--------------------------------------------------------------------------------//
+
+type TransportShardedKeyRequest struct {
+	Table               *Table
+	Value               interface{}
+	WhereConditionArray []*Condition
+}
+
+type TransportShardedKey func(*TransportShardedKeyRequest) (string, bool)
+
+//--------------------------------------------------------------------------------//
+
+func TransportShardedKeyField(fieldSqlName string) TransportShardedKey {
+	return func(request *TransportShardedKeyRequest) (string, bool) {
+		if request == nil || request.Table == nil {
+			return "", false
+		}
+
+		tableField := request.Table.GetFieldBySqlName(fieldSqlName)
+		if tableField == nil {
+			return "", false
+		}
+
+		if request.Value != nil {
+			fieldValue := tableField.helperValue(reflect.ValueOf(request.Value))
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					return "", false
+				}
+
+				fieldValue = fieldValue.Elem()
+			}
+
+			return fmt.Sprint(fieldValue.Interface()), true
+		}
+
+		for _, whereCondition := range request.WhereConditionArray {
+			if whereCondition == nil || whereCondition.GetFieldName() != fieldSqlName || whereCondition.GetFieldPath() != nil || whereCondition.GetOperator() != ConditionOperatorEqual || len(whereCondition.GetValueArray()) != 1 {
+				continue
+			}
+
+			conditionValue := helperValueIndirect(whereCondition.GetValueArray()[0])
+			if conditionValue == nil {
+				continue
+			}
+
+			return fmt.Sprint(conditionValue), true
+		}
+
+		return "", false
+	}
+}
+
+//--------------------------------------------------------------------------------//
+
+type transportSharded struct {
+	Transport
+	database *Database
+
+	mutex            chan interface{}
+	isOpened         bool
+	shardKey         TransportShardedKey
+	shardArray       []Transport
+	shardTxIndexLast int64
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportSharded) helperShardIndex(shardKey string) int {
+	shardHash := fnv.New32a()
+	shardHash.Write([]byte(shardKey))
+
+	return int(shardHash.Sum32() % uint32(len(transport.shardArray)))
+}
+
+func (transport *transportSharded) helperShardRoute(request *TransportShardedKeyRequest) []int {
+	if transport.shardKey != nil {
+		shardKey, ok := transport.shardKey(request)
+		if ok {
+			return []int{transport.helperShardIndex(shardKey)}
+		}
+	}
+
+	shardIndexArray := make([]int, len(transport.shardArray))
+	for shardIndex := range shardIndexArray {
+		shardIndexArray[shardIndex] = shardIndex
+	}
+
+	return shardIndexArray
+}
+
+func (transport *transportSharded) helperShardValue(table *Table, valueArray []interface{}) map[int][]interface{} {
+	shardValueMap := map[int][]interface{}{}
+
+	for _, valueUnit := range valueArray {
+		for _, shardIndex := range transport.helperShardRoute(&TransportShardedKeyRequest{
+			Table: table,
+			Value: valueUnit,
+		}) {
+			shardValueMap[shardIndex] = append(shardValueMap[shardIndex], valueUnit)
+		}
+	}
+
+	return shardValueMap
+}
+
+func (transport *transportSharded) helperShardBuilder(builderRequest Builder) map[int]Builder {
+	shardBuilderMap := map[int]Builder{}
+
+	switch builder := builderRequest.(type) {
+	case *BuilderInsert:
+		for shardIndex, shardValueArray := range transport.helperShardValue(builder.insertTable, builder.insertValue) {
+			shardBuilderMap[shardIndex] = NewBuilderInsert(builder.insertTable).SqlDialect(builder.sqlDialect).Value(shardValueArray...)
+		}
+	case *BuilderReplace:
+		for shardIndex, shardValueArray := range transport.helperShardValue(builder.replaceTable, builder.replaceValue) {
+			shardBuilderMap[shardIndex] = NewBuilderReplace(builder.replaceTable).SqlDialect(builder.sqlDialect).Value(shardValueArray...)
+		}
+	case *BuilderUpdate:
+		for _, shardIndex := range transport.helperShardRoute(&TransportShardedKeyRequest{
+			Table:               builder.updateTable,
+			WhereConditionArray: builder.whereConditionArray,
+		}) {
+			shardBuilderMap[shardIndex] = builder
+		}
+	case *BuilderDelete:
+		for _, shardIndex := range transport.helperShardRoute(&TransportShardedKeyRequest{
+			Table:               builder.deleteTable,
+			WhereConditionArray: builder.whereConditionArray,
+		}) {
+			shardBuilderMap[shardIndex] = builder
+		}
+	case *BuilderSelect:
+		for _, shardIndex := range transport.helperShardRoute(&TransportShardedKeyRequest{
+			Table:               builder.selectTable,
+			WhereConditionArray: builder.whereConditionArray,
+		}) {
+			shardBuilderMap[shardIndex] = builder
+		}
+	default:
+		for shardIndex := range transport.shardArray {
+			shardBuilderMap[shardIndex] = builderRequest
+		}
+	}
+
+	return shardBuilderMap
+}
+
+func (transport *transportSharded) helperShardExecute(builderRequest Builder, shardExecute func(Transport, Builder) error) (err error) {
+	if builderRequest == nil {
+		return ErrorBuilderIsNil
+	}
+
+	shardBuilderMap := transport.helperShardBuilder(builderRequest)
+
+	for shardIndex, shard := range transport.shardArray {
+		shardBuilder, ok := shardBuilderMap[shardIndex]
+		if !ok {
+			continue
+		}
+
+		err = shardExecute(shard, shardBuilder)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (transport *transportSharded) helperShardQuery(builderRequest BuilderWithResponse, shardQuery func(Transport, BuilderWithResponse) (interface{}, error)) (response interface{}, err error) {
+	var (
+		responseUnitTable *Table
+		responseArray     reflect.Value
+		shardResponse     interface{}
+	)
+
+	if builderRequest == nil {
+		err = ErrorBuilderIsNil
+		return
+	}
+
+	responseUnitTable = builderRequest.GetResponseTable()
+	if responseUnitTable == nil {
+		err = ErrorBuilderWithoutResponse
+		return
+	}
+
+	responseArray = reflect.MakeSlice(reflect.SliceOf(responseUnitTable.GetGoType()), 0, 0)
+
+	shardBuilderMap := transport.helperShardBuilder(builderRequest)
+
+	for shardIndex, shard := range transport.shardArray {
+		shardBuilder, ok := shardBuilderMap[shardIndex]
+		if !ok {
+			continue
+		}
+
+		shardResponse, err = shardQuery(shard, shardBuilder.(BuilderWithResponse))
+		if err != nil {
+			return
+		}
+
+		responseArray = reflect.AppendSlice(responseArray, reflect.ValueOf(shardResponse))
+	}
+
+	if builder, ok := builderRequest.(*BuilderSelect); ok && len(shardBuilderMap) > 1 {
+		if len(builder.orderArray) > 0 {
+			responseArray, err = helperShardOrder(responseUnitTable, responseArray, builder.orderArray)
+			if err != nil {
+				return
+			}
+		}
+
+		if builder.limit != nil && int64(responseArray.Len()) > *builder.limit {
+			responseArray = responseArray.Slice(0, int(*builder.limit))
+		}
+	}
+
+	response = responseArray.Interface()
+	return
+}
+
+func helperShardOrder(table *Table, responseArray reflect.Value, orderArray []builderSelectOrder) (reflect.Value, error) {
+	err := helperRowOrderCheck(table, orderArray)
+	if err != nil {
+		return responseArray, err
+	}
+
+	rowArray := make([]transportRow, responseArray.Len())
+	rowIndexArray := make([]int, responseArray.Len())
+
+	for rowIndex := range rowArray {
+		rowArray[rowIndex], err = helperRowFromStruct(table, responseArray.Index(rowIndex).Interface(), true)
+		if err != nil {
+			return responseArray, err
+		}
+
+		rowIndexArray[rowIndex] = rowIndex
+	}
+
+	sort.SliceStable(rowIndexArray, func(i, j int) bool {
+		return helperRowOrderCompare(rowArray[rowIndexArray[i]], rowArray[rowIndexArray[j]], orderArray) < 0
+	})
+
+	responseOrdered := reflect.MakeSlice(responseArray.Type(), 0, responseArray.Len())
+	for _, rowIndex := range rowIndexArray {
+		responseOrdered = reflect.Append(responseOrdered, responseArray.Index(rowIndex))
+	}
+
+	return responseOrdered, nil
+}
+
+//--------------------------------------------------------------------------------//
+
+// Lock holds every shard. LockSqlDb and LockSqlTx do the same and return nil, as
+// no single connection spans the shards.
+func (transport *transportSharded) Lock() {
+	for _, shard := range transport.shardArray {
+		shard.Lock()
+	}
+}
+
+func (transport *transportSharded) LockSqlDb() *sql.DB {
+	transport.Lock()
+	return nil
+}
+
+func (transport *transportSharded) LockSqlTx() *sql.Tx {
+	transport.Lock()
+	return nil
+}
+
+func (transport *transportSharded) Unlock() {
+	for shardIndex := len(transport.shardArray) - 1; shardIndex >= 0; shardIndex-- {
+		transport.shardArray[shardIndex].Unlock()
+	}
+}
+
+func (transport *transportSharded) queryTableIndex(table *Table, sqlAggregate string) (tableIndex int64, err error) {
+	for shardIndex, shard := range transport.shardArray {
+		var shardTableIndex int64
+
+		shardTableIndex, err = helperQueryTableIndex(shard, table, sqlAggregate)
+		if err != nil {
+			err = fmt.Errorf("%w: shard %d", err, shardIndex)
+			return
+		}
+
+		switch sqlAggregate {
+		case "MAX":
+			if shardIndex == 0 || shardTableIndex > tableIndex {
+				tableIndex = shardTableIndex
+			}
+		default:
+			tableIndex += shardTableIndex
+		}
+	}
+
+	return
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportSharded) TransportRegister(database *Database) error {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	if database == nil && transport.database == nil {
+		<-transport.mutex
+		return ErrorTransportMustHaveDatabase
+	}
+
+	if database != nil && database.Transport != nil {
+		<-transport.mutex
+		return ErrorDatabaseIsAlreadyHasTransport
+	}
+
+	if database != nil {
+		for _, shard := range transport.shardArray {
+			database.Transport = nil
+
+			err := shard.TransportRegister(database)
+			if err != nil {
+				database.Transport = nil
+				<-transport.mutex
+				return err
+			}
+		}
+
+		database.Transport = transport
+		transport.database = database
+	}
+
+	<-transport.mutex
+	return nil
+}
+
+func (transport *transportSharded) GetDialect() string {
+	return transport.shardArray[0].GetDialect()
+}
+
+func (transport *transportSharded) Open() (err error) {
+	transport.mutex <- true
+
+	if transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyOpened
+	}
+
+	if len(transport.shardArray) == 0 {
+		<-transport.mutex
+		return ErrorTransportIsNil
+	}
+
+	for _, shard := range transport.shardArray {
+		if shard == nil {
+			<-transport.mutex
+			return ErrorTransportIsNil
+		}
+
+		if shard.GetDialect() != transport.shardArray[0].GetDialect() {
+			<-transport.mutex
+			return ErrorTransportShardedHasMixedDialect
+		}
+	}
+
+	for shardIndex, shard := range transport.shardArray {
+		err = shard.Open()
+		if err != nil {
+			for _, shardOpened := range transport.shardArray[:shardIndex] {
+				shardOpened.Close()
+			}
+
+			<-transport.mutex
+			return
+		}
+	}
+
+	transport.isOpened = true
+
+	<-transport.mutex
+	return
+}
+
+func (transport *transportSharded) Close() (err error) {
+	transport.mutex <- true
+
+	if !transport.isOpened {
+		<-transport.mutex
+		return ErrorTransportIsAlreadyClosed
+	}
+
+	for _, shard := range transport.shardArray {
+		shardError := shard.Close()
+		if shardError != nil && err == nil {
+			err = shardError
+		}
+	}
+
+	transport.isOpened = false
+
+	<-transport.mutex
+	return
+}
+
+func (transport *transportSharded) Execute(builderRequest Builder) error {
+	return transport.helperShardExecute(builderRequest, func(shard Transport, shardBuilder Builder) error {
+		return shard.Execute(shardBuilder)
+	})
+}
+
+func (transport *transportSharded) Query(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.helperShardQuery(builderRequest, func(shard Transport, shardBuilder BuilderWithResponse) (interface{}, error) {
+		return shard.Query(shardBuilder)
+	})
+}
+
+//--------------------------------------------------------------------------------//
+
+func (transport *transportSharded) TransactionOpen() (*Transaction, error) {
+	for shardIndex, shard := range transport.shardArray {
+		_, err := shard.TransactionOpen()
+		if err != nil {
+			for _, shardOpened := range transport.shardArray[:shardIndex] {
+				shardOpened.TransactionRollback()
+			}
+
+			return nil, err
+		}
+	}
+
+	transport.mutex <- true
+	transport.shardTxIndexLast = 0
+	<-transport.mutex
+
+	return NewTransaction(transport)
+}
+
+// TransactionCommit commits the shards one by one and is not atomic across them:
+// once a shard fails the rest are rolled back, but the shards committed before it
+// stay committed. Keep a transaction on a single shard key when that matters.
+func (transport *transportSharded) TransactionCommit() (err error) {
+	for shardIndex, shard := range transport.shardArray {
+		if err != nil {
+			shard.TransactionRollback()
+			continue
+		}
+
+		err = shard.TransactionCommit()
+		if err != nil {
+			err = fmt.Errorf("%w: shard %d", err, shardIndex)
+		}
+	}
+
+	return
+}
+
+func (transport *transportSharded) TransactionRollback() (err error) {
+	for _, shard := range transport.shardArray {
+		shardError := shard.TransactionRollback()
+		if shardError != nil && err == nil {
+			err = shardError
+		}
+	}
+
+	return
+}
+
+func (transport *transportSharded) TransactionStatus() (sqlTxIndexLast int64, sqlTxChangeCount int64, sqlTxError error) {
+	for _, shard := range transport.shardArray {
+		_, shardChangeCount, shardError := shard.TransactionStatus()
+
+		sqlTxChangeCount += shardChangeCount
+		if shardError != nil && sqlTxError == nil {
+			sqlTxError = shardError
+		}
+	}
+
+	transport.mutex <- true
+	sqlTxIndexLast = transport.shardTxIndexLast
+	<-transport.mutex
+
+	return
+}
+
+func (transport *transportSharded) TransactionExecute(builderRequest Builder) error {
+	return transport.helperShardExecute(builderRequest, func(shard Transport, shardBuilder Builder) (err error) {
+		err = shard.TransactionExecute(shardBuilder)
+		if err != nil {
+			return
+		}
+
+		shardTxIndexLast, _, _ := shard.TransactionStatus()
+
+		transport.mutex <- true
+		transport.shardTxIndexLast = shardTxIndexLast
+		<-transport.mutex
+
+		return
+	})
+}
+
+func (transport *transportSharded) TransactionQuery(builderRequest BuilderWithResponse) (interface{}, error) {
+	return transport.helperShardQuery(builderRequest, func(shard Transport, shardBuilder BuilderWithResponse) (interface{}, error) {
+		return shard.TransactionQuery(shardBuilder)
+	})
+}
+
+//--------------------------------------------------------------------------------//
+
+func NewTransportSharded(shardKey TransportShardedKey, shardArray ...Transport) Transport {
+	return &transportSharded{
+		mutex:      make(chan interface{}, 1),
+		shardKey:   shardKey,
+		shardArray: shardArray,
+	}
+}
+
+//--------------------------------------------------------------------------------//
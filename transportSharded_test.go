@@ -0,0 +1,225 @@
+package sqlctrl
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+//--------------------------------------------------------------------------------//
+
+type testShardedUser struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY"`
+	Name string `sql:"NAME=name"`
+}
+
+type testShardedEvent struct {
+	Id   int64  `sql:"NAME=id | PRIMARY_KEY | AUTO_INCREMENT"`
+	Name string `sql:"NAME=name"`
+}
+
+func testShardedOpen(t *testing.T, shardCount int) (*Database, *Table, *transportSharded) {
+	t.Helper()
+
+	shardArray := []Transport{}
+	for shardIndex := 0; shardIndex < shardCount; shardIndex++ {
+		shardArray = append(shardArray, NewTransportSimple("sqlite", filepath.Join(t.TempDir(), fmt.Sprintf("shard%d.db", shardIndex))))
+	}
+
+	transport := NewTransportSharded(TransportShardedKeyField("id"), shardArray...)
+
+	database, err := NewDatabase(transport, NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("users", testShardedUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userArray := []interface{}{}
+	for userId := int64(1); userId <= 12; userId++ {
+		userArray = append(userArray, testShardedUser{Id: userId, Name: fmt.Sprintf("user%d", userId)})
+	}
+
+	err = database.Execute(NewBuilderInsert(table).Value(userArray...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return database, table, transport.(*transportSharded)
+}
+
+//--------------------------------------------------------------------------------//
+
+func TestTransportShardedInsert(t *testing.T) {
+	_, table, transport := testShardedOpen(t, 3)
+
+	userCount := 0
+
+	for shardIndex, shard := range transport.shardArray {
+		response, err := shard.Query(NewBuilderSelect(table))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, user := range response.([]testShardedUser) {
+			if userShard := transport.helperShardIndex(fmt.Sprint(user.Id)); userShard != shardIndex {
+				t.Errorf("user %d is on shard %d, want shard %d", user.Id, shardIndex, userShard)
+			}
+
+			userCount++
+		}
+	}
+
+	if userCount != 12 {
+		t.Errorf("shards hold %d users, want 12", userCount)
+	}
+}
+
+func TestTransportShardedSelectRoute(t *testing.T) {
+	database, table, transport := testShardedOpen(t, 3)
+
+	userShard := transport.helperShardIndex("5")
+	otherShard := (userShard + 1) % len(transport.shardArray)
+
+	err := transport.shardArray[otherShard].Execute(NewBuilderInsert(table).Value(testShardedUser{Id: 5, Name: "stray"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := database.Query(NewBuilderSelect(table).WhereCondition(NewConditionEqual("id", 5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userArray := response.([]testShardedUser)
+	if len(userArray) != 1 || userArray[0].Name != "user5" {
+		t.Errorf("routed select returned %v, want only user5 from shard %d", userArray, userShard)
+	}
+}
+
+func TestTransportShardedScatterGather(t *testing.T) {
+	database, table, _ := testShardedOpen(t, 3)
+
+	response, err := database.Query(NewBuilderSelect(table).OrderByDesc("id").Limit(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userArray := response.([]testShardedUser)
+	if len(userArray) != 4 {
+		t.Fatalf("scatter-gather returned %d users, want 4", len(userArray))
+	}
+
+	for userIndex, user := range userArray {
+		if user.Id != int64(12-userIndex) {
+			t.Errorf("user %d has id %d, want %d", userIndex, user.Id, 12-userIndex)
+		}
+	}
+
+	response, err = database.Query(NewBuilderSelect(table).Where("id > 3").OrderBy("id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userArray = response.([]testShardedUser)
+	if len(userArray) != 9 || userArray[0].Id != 4 || userArray[8].Id != 12 {
+		t.Errorf("raw where scatter returned %v, want ids 4..12", userArray)
+	}
+}
+
+func TestTransportShardedRegister(t *testing.T) {
+	database, _, transport := testShardedOpen(t, 2)
+
+	for shardIndex, shard := range transport.shardArray {
+		if shard.(*transportSimple).database != database {
+			t.Errorf("shard %d is not registered with the database", shardIndex)
+		}
+	}
+}
+
+func TestTransportShardedTableIndex(t *testing.T) {
+	shardArray := []Transport{
+		NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "shard0.db")),
+		NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "shard1.db")),
+	}
+
+	database, err := NewDatabase(NewTransportSharded(TransportShardedKeyField("name"), shardArray...), NewSchemeMemory("scheme", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	table, err := database.RegisterTable("events", testShardedEvent{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for eventIndex := 0; eventIndex < 6; eventIndex++ {
+		err = database.Execute(NewBuilderInsert(table).Value(testShardedEvent{Name: fmt.Sprintf("event%d", eventIndex)}))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		shardCount int64
+		shardLast  int64
+	)
+
+	for _, shard := range shardArray {
+		response, err := shard.Query(NewBuilderSelect(table))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, event := range response.([]testShardedEvent) {
+			shardCount++
+
+			if event.Id > shardLast {
+				shardLast = event.Id
+			}
+		}
+	}
+
+	indexCount, err := database.QueryTableIndexCount(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if indexCount != 6 || shardCount != 6 {
+		t.Errorf("index count is %d over %d rows, want 6", indexCount, shardCount)
+	}
+
+	indexLast, err := database.QueryTableIndexLast(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if indexLast != shardLast {
+		t.Errorf("index last is %d, want the largest shard index %d", indexLast, shardLast)
+	}
+}
+
+func TestTransportShardedMixedDialect(t *testing.T) {
+	transport := NewTransportSharded(nil, NewTransportSimple("sqlite", filepath.Join(t.TempDir(), "shard.db")), NewTransportMemory())
+
+	err := transport.Open()
+	if !errors.Is(err, ErrorTransportShardedHasMixedDialect) {
+		t.Errorf("opening sqlite and memory shards returned %v, want a mixed dialect error", err)
+	}
+}
+
+//--------------------------------------------------------------------------------//
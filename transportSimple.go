@@ -1,8 +1,12 @@
 package sqlctrl
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"reflect"
+	"time"
 )
 
 //--------------------------------------------------------------------------------//
@@ -16,6 +20,12 @@ type transportSimple struct {
 	sqlSource        string
 	sqlDb            *sql.DB
 	sqlTx            *sql.Tx
+	sqlIndexLast     int64
+	sqlChangeCount   int64
+	sqlTxConn        *sql.Conn
+	sqlTxReference   bool
+	sqlTxTableArray  []string
+	sqlTxBusyTimeout int64
 	sqlTxError       error
 	sqlTxIndexLast   int64
 	sqlTxChangeCount int64
@@ -23,6 +33,66 @@ type transportSimple struct {
 
 //--------------------------------------------------------------------------------//
 
+type transportSimpleConnector struct {
+	sqlDriver    driver.Driver
+	sqlSource    string
+	sqlInitArray []string
+}
+
+func (connector *transportSimpleConnector) Connect(ctx context.Context) (sqlConn driver.Conn, err error) {
+	if driverContext, ok := connector.sqlDriver.(driver.DriverContext); ok {
+		var sqlConnector driver.Connector
+
+		sqlConnector, err = driverContext.OpenConnector(connector.sqlSource)
+		if err != nil {
+			return
+		}
+
+		sqlConn, err = sqlConnector.Connect(ctx)
+	} else {
+		sqlConn, err = connector.sqlDriver.Open(connector.sqlSource)
+	}
+
+	if err != nil {
+		return
+	}
+
+	for _, sqlInit := range connector.sqlInitArray {
+		err = connector.helperExecute(ctx, sqlConn, sqlInit)
+		if err != nil {
+			sqlConn.Close()
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func (connector *transportSimpleConnector) Driver() driver.Driver {
+	return connector.sqlDriver
+}
+
+func (connector *transportSimpleConnector) helperExecute(ctx context.Context, sqlConn driver.Conn, sqlInit string) error {
+	if sqlExecer, ok := sqlConn.(driver.ExecerContext); ok {
+		_, err := sqlExecer.ExecContext(ctx, sqlInit, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+
+	sqlStmt, err := sqlConn.Prepare(sqlInit)
+	if err != nil {
+		return err
+	}
+
+	defer sqlStmt.Close()
+
+	_, err = sqlStmt.Exec(nil)
+	return err
+}
+
+//--------------------------------------------------------------------------------//
+
 func (transport *transportSimple) helperSqlRowsToInterface(sqlRowArray *sql.Rows, responseUnitTable *Table) (response interface{}, err error) {
 	var (
 		responseArray          reflect.Value
@@ -43,6 +113,10 @@ func (transport *transportSimple) helperSqlRowsToInterface(sqlRowArray *sql.Rows
 			return
 		}
 
+		for fieldIndex, fieldGoName := range responseUnitTable.GetGoFieldNameArray() {
+			responseUnitFieldArray[fieldIndex] = responseUnitTable.GetFieldByGoName(fieldGoName).helperScan(responseUnitFieldArray[fieldIndex])
+		}
+
 		err = sqlRowArray.Scan(responseUnitFieldArray...)
 		if err != nil {
 			return
@@ -104,6 +178,10 @@ func (transport *transportSimple) TransportRegister(database *Database) error {
 	return nil
 }
 
+func (transport *transportSimple) GetDialect() string {
+	return transport.sqlDriver
+}
+
 func (transport *transportSimple) Open() (err error) {
 	transport.mutex <- true
 
@@ -118,6 +196,17 @@ func (transport *transportSimple) Open() (err error) {
 		return
 	}
 
+	if transport.sqlDriver == "sqlite" {
+		sqlDriver := transport.sqlDb.Driver()
+		transport.sqlDb.Close()
+
+		transport.sqlDb = sql.OpenDB(&transportSimpleConnector{
+			sqlDriver:    sqlDriver,
+			sqlSource:    transport.sqlSource,
+			sqlInitArray: []string{transport.helperReferenceSwitch(true)},
+		})
+	}
+
 	<-transport.mutex
 	return
 }
@@ -170,12 +259,21 @@ func (transport *transportSimple) Execute(builderRequest Builder) (transportErro
 		return builderError
 	}
 
-	_, transportError = transport.sqlDb.Exec(builderString, builderOption...)
+	transport.sqlIndexLast, transport.sqlChangeCount = 0, 0
+
+	sqlResult, transportError := transport.sqlDb.Exec(builderString, builderOption...)
 	if transportError != nil {
 		<-transport.mutex
 		return
 	}
 
+	transport.sqlIndexLast, _ = sqlResult.LastInsertId()
+	transport.sqlChangeCount, _ = sqlResult.RowsAffected()
+
+	if versionBuilder, ok := builderRequest.(builderWithVersionCheck); ok {
+		transportError = versionBuilder.helperVersionCheck(transport.sqlChangeCount)
+	}
+
 	<-transport.mutex
 	return
 }
@@ -223,6 +321,10 @@ func (transport *transportSimple) Query(builderRequest BuilderWithResponse) (res
 	return transport.helperSqlRowsToInterface(sqlRowArray, responseUnitTable)
 }
 
+func (transport *transportSimple) executeStatus() (int64, int64) {
+	return transport.sqlIndexLast, transport.sqlChangeCount
+}
+
 //--------------------------------------------------------------------------------//
 
 func (transport *transportSimple) TransactionOpen() (*Transaction, error) {
@@ -258,21 +360,186 @@ func (transport *transportSimple) TransactionOpen() (*Transaction, error) {
 	return transaction, err
 }
 
-func (transport *transportSimple) TransactionCommit() (err error) {
+func (transport *transportSimple) transactionOpenWithoutReference() (*Transaction, error) {
+	var (
+		transaction *Transaction
+		sqlConn     *sql.Conn
+		err         error
+		ctx         = context.Background()
+	)
+
+	switch transport.sqlDriver {
+	case "sqlite":
+	case "mysql":
+	default:
+		return transport.TransactionOpen()
+	}
+
 	transport.mutex <- true
 
-	if transport.sqlTx == nil {
+	if transport.sqlDb == nil {
 		<-transport.mutex
-		return ErrorTransactionIsAlreadyClosed
+		return nil, ErrorTransportIsAlreadyClosed
+	}
+
+	if transport.sqlTx != nil {
+		<-transport.mutex
+		return nil, ErrorTransactionIsAlreadyOpened
 	}
 
-	err = transport.sqlTx.Commit()
+	sqlConn, err = transport.sqlDb.Conn(ctx)
 	if err != nil {
 		<-transport.mutex
+		return nil, err
+	}
+
+	err = sqlConn.QueryRowContext(ctx, transport.helperReferenceState()).Scan(&transport.sqlTxReference)
+	if err == nil {
+		_, err = sqlConn.ExecContext(ctx, transport.helperReferenceSwitch(false))
+	}
+
+	if err == nil {
+		transport.sqlTxBusyTimeout, err = transport.helperBusyTimeout(ctx, sqlConn, int64(SchemeLockTimeout/time.Millisecond))
+	}
+
+	if err == nil {
+		transport.sqlTx, err = sqlConn.BeginTx(ctx, nil)
+	}
+
+	if err != nil {
+		sqlConn.ExecContext(ctx, transport.helperReferenceSwitch(transport.sqlTxReference))
+		transport.helperBusyTimeout(ctx, sqlConn, transport.sqlTxBusyTimeout)
+		sqlConn.Close()
+		<-transport.mutex
+		return nil, err
+	}
+
+	transport.sqlTxConn = sqlConn
+
+	transaction, err = NewTransaction(transport)
+	transport.sqlTxError = err
+	transport.sqlTxIndexLast = 0
+	transport.sqlTxChangeCount = 0
+	<-transport.mutex
+
+	return transaction, err
+}
+
+func (transport *transportSimple) helperBusyTimeout(ctx context.Context, sqlConn *sql.Conn, busyTimeout int64) (busyTimeoutLast int64, err error) {
+	if transport.sqlDriver != "sqlite" {
+		return
+	}
+
+	err = sqlConn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeoutLast)
+	if err != nil {
 		return
 	}
 
+	_, err = sqlConn.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d", busyTimeout))
+	return
+}
+
+func (transport *transportSimple) helperReferenceState() string {
+	switch transport.sqlDriver {
+	case "sqlite":
+		return "PRAGMA foreign_keys"
+	case "mysql":
+		return "SELECT @@FOREIGN_KEY_CHECKS"
+	}
+
+	return ""
+}
+
+func (transport *transportSimple) helperReferenceSwitch(enabled bool) string {
+	switch transport.sqlDriver {
+	case "sqlite":
+		if enabled {
+			return "PRAGMA foreign_keys = ON"
+		}
+
+		return "PRAGMA foreign_keys = OFF"
+	case "mysql":
+		if enabled {
+			return "SET FOREIGN_KEY_CHECKS = 1"
+		}
+
+		return "SET FOREIGN_KEY_CHECKS = 0"
+	}
+
+	return ""
+}
+
+func (transport *transportSimple) helperReferenceCheck() error {
+	if transport.sqlTxConn == nil || transport.sqlDriver != "sqlite" {
+		return nil
+	}
+
+	for _, tableName := range transport.sqlTxTableArray {
+		err := transport.helperReferenceCheckTable(tableName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (transport *transportSimple) helperReferenceCheckTable(tableName string) error {
+	sqlRowArray, err := transport.sqlTx.Query(fmt.Sprintf("PRAGMA foreign_key_check(`%s`)", tableName))
+	if err != nil {
+		return err
+	}
+
+	defer sqlRowArray.Close()
+
+	if sqlRowArray.Next() {
+		var (
+			tableName  string
+			tableRowId sql.NullInt64
+			parentName string
+			parentId   int64
+		)
+
+		err = sqlRowArray.Scan(&tableName, &tableRowId, &parentName, &parentId)
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%w: %s references %s", ErrorTransactionViolatesReference, tableName, parentName)
+	}
+
+	return sqlRowArray.Err()
+}
+
+func (transport *transportSimple) helperReferenceRelease() {
+	if transport.sqlTxConn == nil {
+		return
+	}
+
+	transport.sqlTxConn.ExecContext(context.Background(), transport.helperReferenceSwitch(transport.sqlTxReference))
+	transport.helperBusyTimeout(context.Background(), transport.sqlTxConn, transport.sqlTxBusyTimeout)
+	transport.sqlTxConn.Close()
+	transport.sqlTxConn = nil
+	transport.sqlTxTableArray = nil
+}
+
+func (transport *transportSimple) TransactionCommit() (err error) {
+	transport.mutex <- true
+
+	if transport.sqlTx == nil {
+		<-transport.mutex
+		return ErrorTransactionIsAlreadyClosed
+	}
+
+	err = transport.helperReferenceCheck()
+	if err == nil {
+		err = transport.sqlTx.Commit()
+	} else {
+		transport.sqlTx.Rollback()
+	}
+
 	transport.sqlTx = nil
+	transport.helperReferenceRelease()
 	<-transport.mutex
 
 	return
@@ -287,12 +554,8 @@ func (transport *transportSimple) TransactionRollback() (err error) {
 	}
 
 	err = transport.sqlTx.Rollback()
-	if err != nil {
-		<-transport.mutex
-		return
-	}
-
 	transport.sqlTx = nil
+	transport.helperReferenceRelease()
 	<-transport.mutex
 
 	return
@@ -356,6 +619,14 @@ func (transport *transportSimple) TransactionExecute(builderRequest Builder) (tr
 	}
 	transport.sqlTxChangeCount += sqlTxChangeCount
 
+	if alterBuilder, ok := builderRequest.(*BuilderAlter); ok && transport.sqlTxConn != nil {
+		transport.sqlTxTableArray = append(transport.sqlTxTableArray, alterBuilder.helperTableName())
+	}
+
+	if versionBuilder, ok := builderRequest.(builderWithVersionCheck); ok {
+		transactionError = versionBuilder.helperVersionCheck(sqlTxChangeCount)
+	}
+
 	return
 }
 
@@ -0,0 +1,151 @@
+package sqlctrl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------//
+
+type transportRow map[string]interface{}
+
+//--------------------------------------------------------------------------------//
+
+func helperRowFromStruct(table *Table, valueUnit interface{}, withAutoIncrement bool) (row transportRow, err error) {
+	valueReflectValue := reflect.ValueOf(valueUnit)
+
+	if valueReflectValue.Type() != table.GetGoType() {
+		err = ErrorBuilderValueHasUnsupportedType
+		return
+	}
+
+	row = transportRow{}
+	for _, fieldGoName := range table.GetGoFieldNameArray() {
+		tableField := table.GetFieldByGoName(fieldGoName)
+
+		if tableField.IsAutoIncrement() && !withAutoIncrement {
+			continue
+		}
+
+		if tableField.IsJson() || tableField.IsTime() || tableField.isValuer {
+			row[tableField.GetSqlName()], err = tableField.helperValueEncode(tableField.helperValue(valueReflectValue))
+			if err != nil {
+				return
+			}
+
+			continue
+		}
+
+		row[tableField.GetSqlName()] = helperValueIndirect(tableField.helperValue(valueReflectValue).Interface())
+	}
+
+	return
+}
+
+func helperValueIndirect(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return nil
+		}
+
+		reflectValue = reflectValue.Elem()
+	}
+
+	return reflectValue.Interface()
+}
+
+func helperValueNumber(value interface{}) (float64, bool) {
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Bool:
+		if reflectValue.Bool() {
+			return 1, true
+		}
+
+		return 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflectValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true
+	case reflect.String:
+		valueFloat, err := strconv.ParseFloat(reflectValue.String(), 64)
+		return valueFloat, err == nil
+	}
+
+	return 0, false
+}
+
+func helperValueCompare(valueLeft interface{}, valueRight interface{}) int {
+	valueLeftString, okLeft := valueLeft.(string)
+	valueRightString, okRight := valueRight.(string)
+	if okLeft && okRight {
+		return strings.Compare(valueLeftString, valueRightString)
+	}
+
+	valueLeftNumber, okLeft := helperValueNumber(valueLeft)
+	valueRightNumber, okRight := helperValueNumber(valueRight)
+	if okLeft && okRight {
+		switch {
+		case valueLeftNumber < valueRightNumber:
+			return -1
+		case valueLeftNumber > valueRightNumber:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(valueLeft), fmt.Sprint(valueRight))
+}
+
+func helperRowOrderCheck(table *Table, orderArray []builderSelectOrder) error {
+	for _, orderUnit := range orderArray {
+		if table.GetFieldBySqlName(orderUnit.fieldName) == nil {
+			return fmt.Errorf("%w: %s", ErrorBuilderMustHaveAField, orderUnit.fieldName)
+		}
+	}
+
+	return nil
+}
+
+func helperRowOrderCompare(rowLeft transportRow, rowRight transportRow, orderArray []builderSelectOrder) int {
+	for _, orderUnit := range orderArray {
+		var (
+			valueLeft    = helperValueIndirect(rowLeft[orderUnit.fieldName])
+			valueRight   = helperValueIndirect(rowRight[orderUnit.fieldName])
+			valueCompare int
+		)
+
+		switch {
+		case valueLeft == nil && valueRight == nil:
+		case valueLeft == nil:
+			valueCompare = -1
+		case valueRight == nil:
+			valueCompare = 1
+		default:
+			valueCompare = helperValueCompare(valueLeft, valueRight)
+		}
+
+		if orderUnit.isDesc {
+			valueCompare = -valueCompare
+		}
+
+		if valueCompare != 0 {
+			return valueCompare
+		}
+	}
+
+	return 0
+}
+
+//--------------------------------------------------------------------------------//